@@ -1,6 +1,7 @@
 package chaos
 
 import (
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -24,6 +25,9 @@ func (c *Chaos) Int32(n int32) int32 {
 	if n <= 0 {
 		return 0
 	}
+	if c.run != nil {
+		return int32(c.choose(uint64(n)))
+	}
 	r := c.rand()
 	return r.Int31n(n + 1)
 }
@@ -46,6 +50,9 @@ func (c *Chaos) Int32Between(min, max int32) int32 {
 	if min > max {
 		min, max = max, min
 	}
+	if c.run != nil && min < 0 && max > 0 {
+		return int32(c.chooseNearZero(int64(min), int64(max)))
+	}
 	return c.Int32(max-min) + min
 }
 
@@ -67,6 +74,9 @@ func (c *Chaos) Int64(n int64) int64 {
 	if n <= 0 {
 		return 0
 	}
+	if c.run != nil {
+		return int64(c.choose(uint64(n)))
+	}
 	r := c.rand()
 	return r.Int63n(n + 1)
 }
@@ -84,14 +94,56 @@ func Int64Between(min, max int64) int64 {
 // Behavior:
 //   - If min > max, the values are swapped.
 //   - The generated integer is guaranteed to be within the range [min, max],
-//     including both min and max as possible values.
+//     including both min and max as possible values, even when the range is wider than math.MaxInt64.
 func (c *Chaos) Int64Between(min, max int64) int64 {
 	if min > max {
 		min, max = max, min
 	}
+	if c.run != nil && min < 0 && max > 0 {
+		return c.chooseNearZero(min, max)
+	}
+	if uint64(max)-uint64(min) >= math.MaxInt64 {
+		return c.int64Between(min, max)
+	}
 	return c.Int64(max-min) + min
 }
 
+// Uint64Between generates a deterministic unsigned integer between min and max (inclusive) based on the provided seed.
+// Behavior:
+//   - If min > max, the values are swapped.
+//   - The generated integer is guaranteed to be within the range [min, max],
+//     including both min and max as possible values, up to the full range of uint64.
+func Uint64Between(min, max uint64) uint64 {
+	return singleton.Uint64Between(min, max)
+}
+
+// Uint64Between generates a deterministic unsigned integer between min and max (inclusive) based on the provided seed.
+// Behavior:
+//   - If min > max, the values are swapped.
+//   - The generated integer is guaranteed to be within the range [min, max],
+//     including both min and max as possible values, up to the full range of uint64.
+func (c *Chaos) Uint64Between(min, max uint64) uint64 {
+	if min > max {
+		min, max = max, min
+	}
+	span := max - min
+	if c.run != nil {
+		return min + c.choose(span)
+	}
+	r := c.rand()
+	if span == math.MaxUint64 {
+		return r.Uint64()
+	}
+	n := span + 1
+	// Values below threshold are rejected to avoid the modulo bias.
+	threshold := -n % n
+	for {
+		if x := r.Uint64(); x >= threshold {
+			return min + x%n
+		}
+	}
+}
+
 // Int generates a deterministic integer between 0 and n (inclusive) based on the provided seed.
 // Behavior:
 //   - If n <= 0, the function returns 0.
@@ -110,6 +162,9 @@ func (c *Chaos) Int(n int) int {
 	if n <= 0 {
 		return 0
 	}
+	if c.run != nil {
+		return int(c.choose(uint64(n)))
+	}
 	r := c.rand()
 	return r.Intn(n + 1)
 }
@@ -132,6 +187,9 @@ func (c *Chaos) IntBetween(min, max int) int {
 	if min > max {
 		min, max = max, min
 	}
+	if c.run != nil && min < 0 && max > 0 {
+		return int(c.chooseNearZero(int64(min), int64(max)))
+	}
 	return c.Int(max-min) + min
 }
 
@@ -193,6 +251,9 @@ func Float32(n float32) float32 {
 
 // Float32 returns a deterministic float32 between 0 and n.
 func (c *Chaos) Float32(n float32) float32 {
+	if c.run != nil {
+		return float32(c.choose(1<<24-1)) / (1 << 24) * n
+	}
 	r := c.rand()
 	return r.Float32() * n
 }
@@ -214,6 +275,9 @@ func Float64(n float64) float64 {
 
 // Float64 returns a deterministic float64 between 0 and n.
 func (c *Chaos) Float64(n float64) float64 {
+	if c.run != nil {
+		return float64(c.choose(1<<53-1)) / (1 << 53) * n
+	}
 	r := c.rand()
 	return r.Float64() * n
 }
@@ -245,6 +309,40 @@ func (c *Chaos) String(length int) string {
 	return ret
 }
 
+// StringFrom returns a random string of <length> characters picked from charset.
+func StringFrom(charset string, length int) string {
+	return singleton.StringFrom(charset, length)
+}
+
+// StringFrom returns a deterministic string of <length> characters picked from charset.
+// If charset is empty or length <= 0, an empty string is returned.
+func (c *Chaos) StringFrom(charset string, length int) string {
+	chars := []rune(charset)
+	if len(chars) == 0 || length <= 0 {
+		return ""
+	}
+	ret := make([]rune, 0, length)
+	for i := 0; i < length; i++ {
+		ret = append(ret, chars[c.Int(len(chars)-1)])
+	}
+	return string(ret)
+}
+
+// Bytes returns a slice of n random bytes.
+func Bytes(n int) []byte {
+	return singleton.Bytes(n)
+}
+
+// Bytes returns a slice of n deterministic bytes.
+func (c *Chaos) Bytes(n int) []byte {
+	if n <= 0 {
+		return []byte{}
+	}
+	ret := make([]byte, n)
+	_, _ = c.rand().Read(ret)
+	return ret
+}
+
 // IntSlice returns a slice of random numbers between 0 and high included.
 // The length of the slice is length.
 func IntSlice(high int, length int) []int {
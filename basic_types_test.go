@@ -94,6 +94,73 @@ func TestIntBetween(t *testing.T) {
 	})
 }
 
+func TestInt64Between(t *testing.T) {
+	t.Run("fixed chaos produces the same output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.Int64Between(-100, 100), c.Int64Between(-100, 100))
+	})
+
+	t.Run("respects bounds", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 1000; i++ {
+			result := c.Int64Between(20, -10)
+			assert.GreaterOrEqual(t, result, int64(-10))
+			assert.LessOrEqual(t, result, int64(20))
+		}
+	})
+
+	t.Run("edge case: full range", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		negative, positive := false, false
+		for i := 0; i < 100; i++ {
+			result := c.Int64Between(math.MinInt64, math.MaxInt64)
+			negative = negative || result < 0
+			positive = positive || result > 0
+		}
+		assert.True(t, negative)
+		assert.True(t, positive)
+	})
+
+	t.Run("edge case: span of MaxInt64", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 100; i++ {
+			assert.GreaterOrEqual(t, c.Int64Between(0, math.MaxInt64), int64(0))
+		}
+	})
+}
+
+func TestUint64Between(t *testing.T) {
+	t.Run("fixed chaos produces the same output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.Uint64Between(0, 100), c.Uint64Between(0, 100))
+	})
+
+	t.Run("respects bounds", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 1000; i++ {
+			result := c.Uint64Between(20, 10)
+			assert.GreaterOrEqual(t, result, uint64(10))
+			assert.LessOrEqual(t, result, uint64(20))
+		}
+	})
+
+	t.Run("edge case: full range", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		high := false
+		for i := 0; i < 100; i++ {
+			high = high || c.Uint64Between(0, math.MaxUint64) > math.MaxInt64
+		}
+		assert.True(t, high)
+	})
+
+	t.Run("edge case: min equals max", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		assert.Equal(t, uint64(math.MaxUint64), c.Uint64Between(math.MaxUint64, math.MaxUint64))
+	})
+}
+
 func TestBool(t *testing.T) {
 	t.Run("deterministic output", func(t *testing.T) {
 		c := chaos.New(t.Name())
@@ -459,3 +526,41 @@ func TestUUID(t *testing.T) {
 		}
 	})
 }
+
+func TestStringFrom(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.StringFrom("abc", 10), c.StringFrom("abc", 10))
+	})
+
+	t.Run("only uses characters from the charset", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		result := c.StringFrom("xyzé", 1000)
+		assert.Regexp(t, `^[xyzé]{1000}$`, result)
+	})
+
+	t.Run("edge case: empty charset", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		assert.Empty(t, c.StringFrom("", 10))
+	})
+
+	t.Run("edge case: negative length", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		assert.Empty(t, c.StringFrom("abc", -1))
+	})
+}
+
+func TestBytes(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.Bytes(16), c.Bytes(16))
+	})
+
+	t.Run("respects length", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		assert.Len(t, c.Bytes(32), 32)
+		assert.Empty(t, c.Bytes(0))
+	})
+}
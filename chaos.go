@@ -4,13 +4,22 @@ import (
 	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
+	"math"
 	"math/rand"
+	"reflect"
 )
 
 type Chaos struct {
-	count int
-	fixed bool
-	seed  string
+	count   int
+	fixed   bool
+	seed    string
+	uniques map[string]*UniqueGenerator
+	// generators are the generators registered with RegisterFor.
+	generators map[reflect.Type]func(c *Chaos) reflect.Value
+	// size is the size of the values generated by sized generators, see Sized.
+	size int
+	// run records the case run by Check, see Input.
+	run *propertyRun
 }
 
 func New(seed string) *Chaos {
@@ -18,6 +27,7 @@ func New(seed string) *Chaos {
 		count: 0,
 		fixed: false,
 		seed:  seed,
+		size:  defaultSize,
 	}
 }
 
@@ -35,6 +45,15 @@ func (c *Chaos) Unfix() {
 }
 
 func (c *Chaos) rand() *rand.Rand {
+	if c.run != nil {
+		// The source is a choice, so a case run by Check can be replayed.
+		return rand.New(rand.NewSource(int64(c.choose(math.MaxUint64))))
+	}
+	return c.seededRand()
+}
+
+// seededRand returns a source seeded from the seed and the count of c.
+func (c *Chaos) seededRand() *rand.Rand {
 	if !c.fixed {
 		c.count++
 	}
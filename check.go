@@ -0,0 +1,153 @@
+package chaos
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+// seedEnv is the environment variable selecting the case run by Check, see Check.
+const seedEnv = "CHAOS_SEED"
+
+// defaultRuns is the number of cases run by Check, unless changed by Runs.
+const defaultRuns = 100
+
+// CheckOption configures Check.
+type CheckOption func(cfg *checkConfig)
+
+type checkConfig struct {
+	runs    int
+	shrinks int
+	seed    string
+}
+
+// Runs sets the number of cases run by Check. Values below 1 are treated as 1.
+func Runs(n int) CheckOption {
+	return func(cfg *checkConfig) {
+		cfg.runs = max(n, 1)
+	}
+}
+
+// Shrinks sets the maximum number of runs spent shrinking a failing case, 1000 by default.
+// 0 disables shrinking.
+func Shrinks(n int) CheckOption {
+	return func(cfg *checkConfig) {
+		cfg.shrinks = max(n, 0)
+	}
+}
+
+// Seed sets the seed the seeds of the cases run by Check are derived from.
+// It defaults to the name of the test, so properties of different tests draw different values.
+func Seed(seed string) CheckOption {
+	return func(cfg *checkConfig) {
+		cfg.seed = seed
+	}
+}
+
+// Check tests that property holds for many cases, 100 unless changed by Runs.
+// Each case is given a new chaos, with a seed derived from the seed of the check, so cases are
+// deterministic: the case of seed "TestSort#12" always draws the same values.
+// The values drawn with Input are the inputs of the case.
+//
+//	chaos.Check(t, func(c *chaos.Chaos) bool {
+//		xs := chaos.Input(c, "xs", chaos.SliceOf(chaos.IntGen(0, 100), 0, 20))
+//		return slices.IsSorted(Sort(xs))
+//	}, chaos.Runs(500))
+//
+// Check stops at the first case for which property returns false or panics, and shrinks it:
+// it replays the case with simpler draws, like integers closer to zero, shorter strings and
+// slices, or items picked closer to the start of slices, for as long as property still fails,
+// see Shrinks. It then reports the simplest failing case with t.Errorf, along with its seed
+// and inputs. Setting the CHAOS_SEED environment variable to the seed of a case runs only
+// this case, shrunk the same way, to debug it:
+//
+//	CHAOS_SEED='TestSort#12' go test -run TestSort
+//
+// Check reports whether property held for every case.
+func Check(t testing.TB, property func(c *Chaos) bool, options ...CheckOption) bool {
+	t.Helper()
+	cfg := checkConfig{runs: defaultRuns, shrinks: defaultShrinks, seed: t.Name()}
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	if seed := os.Getenv(seedEnv); strings.HasPrefix(seed, cfg.seed+"#") {
+		return checkCase(t, property, cfg, seed, 1)
+	}
+	for i := 0; i < cfg.runs; i++ {
+		if !checkCase(t, property, cfg, fmt.Sprintf("%s#%d", cfg.seed, i), i+1) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkCase runs the case of property with seed, the nth case of the check,
+// and reports it, shrunk, if it fails.
+func checkCase(t testing.TB, property func(c *Chaos) bool, cfg checkConfig, seed string, n int) bool {
+	t.Helper()
+	run := &propertyRun{}
+	c := New(seed)
+	c.run = run
+	if run.check(c, property) {
+		return true
+	}
+	s := &shrinker{property: property, seed: seed, best: run, budget: cfg.shrinks}
+	s.shrink()
+
+	var report strings.Builder
+	fmt.Fprintf(&report, "chaos: property failed on case %d with seed %q", n, seed)
+	if runs := cfg.shrinks - s.budget; runs > 0 {
+		fmt.Fprintf(&report, ", shrunk in %d runs", runs)
+	}
+	if s.best.panicked != nil {
+		fmt.Fprintf(&report, ": panic: %v", s.best.panicked)
+	}
+	for _, in := range s.best.inputs {
+		fmt.Fprintf(&report, "\n\t%s: %#v", in.name, in.value)
+	}
+	fmt.Fprintf(&report, "\nrun only this case with %s=%q", seedEnv, seed)
+	t.Errorf("%s", report.String())
+	return false
+}
+
+// propertyRun records a case run by Check.
+type propertyRun struct {
+	inputs   []input
+	panicked any
+	// choices are the choices drawn by the case, see choose.
+	choices []uint64
+	// lists record the items of the slices generated by the case, see repeat.
+	lists []list
+	// prefix are the choices replayed when replay is set.
+	prefix []uint64
+	replay bool
+}
+
+// input is a value drawn with Input.
+type input struct {
+	name  string
+	value any
+}
+
+// check reports whether property holds with c. A panic is a failure, recorded in r.
+func (r *propertyRun) check(c *Chaos, property func(c *Chaos) bool) (ok bool) {
+	defer func() {
+		if p := recover(); p != nil {
+			r.panicked = p
+			ok = false
+		}
+	}()
+	return property(c)
+}
+
+// Input returns a value generated by g with c, recorded as the named input of the case run by Check,
+// so it is reported if the property fails. Outside of Check, it is the same as g.Generate(c).
+func Input[T any](c *Chaos, name string, g Gen[T]) T {
+	v := g.Generate(c)
+	if c.run != nil {
+		c.run.inputs = append(c.run.inputs, input{name: name, value: v})
+	}
+	return v
+}
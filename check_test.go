@@ -0,0 +1,130 @@
+package chaos_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/raphoester/chaos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder is a testing.TB recording the failures reported by Check.
+type recorder struct {
+	testing.TB
+	name   string
+	errors []string
+}
+
+func (r *recorder) Helper()      {}
+func (r *recorder) Name() string { return r.name }
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestCheck(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		draws := func() []int {
+			var xs []int
+			chaos.Check(t, func(c *chaos.Chaos) bool {
+				xs = append(xs, c.Int(1000))
+				return true
+			}, chaos.Runs(20))
+			return xs
+		}
+		assert.Equal(t, draws(), draws())
+	})
+
+	t.Run("runs the property with different seeds", func(t *testing.T) {
+		runs := 0
+		seen := make(map[int]bool)
+		ok := chaos.Check(t, func(c *chaos.Chaos) bool {
+			runs++
+			seen[c.Int(1_000_000)] = true
+			return true
+		}, chaos.Runs(500))
+		assert.True(t, ok)
+		assert.Equal(t, 500, runs)
+		assert.Greater(t, len(seen), 490)
+	})
+
+	t.Run("defaults to 100 runs", func(t *testing.T) {
+		runs := 0
+		chaos.Check(t, func(c *chaos.Chaos) bool {
+			runs++
+			return true
+		})
+		assert.Equal(t, 100, runs)
+	})
+
+	t.Run("reports the failing seed and inputs", func(t *testing.T) {
+		r := &recorder{name: "TestSum"}
+		runs := 0
+		ok := chaos.Check(r, func(c *chaos.Chaos) bool {
+			runs++
+			x := chaos.Input(c, "x", chaos.IntGen(0, 100))
+			return x < 90
+		}, chaos.Runs(1000), chaos.Shrinks(0))
+		assert.False(t, ok)
+		assert.Less(t, runs, 1000, "stops at the first failure")
+		require.Len(t, r.errors, 1)
+		seed := fmt.Sprintf("TestSum#%d", runs-1)
+		assert.Contains(t, r.errors[0], fmt.Sprintf("property failed on case %d with seed %q", runs, seed))
+		assert.Regexp(t, `\n\tx: (9\d|100)\n`, r.errors[0])
+		assert.Contains(t, r.errors[0], fmt.Sprintf("CHAOS_SEED=%q", seed))
+	})
+
+	t.Run("reports panics", func(t *testing.T) {
+		r := &recorder{name: "TestPanic"}
+		ok := chaos.Check(r, func(c *chaos.Chaos) bool {
+			panic("boom")
+		})
+		assert.False(t, ok)
+		require.Len(t, r.errors, 1)
+		assert.Contains(t, r.errors[0], `seed "TestPanic#0": panic: boom`)
+	})
+
+	t.Run("reruns the case of the seed variable", func(t *testing.T) {
+		property := func(runs *int, x *int) func(c *chaos.Chaos) bool {
+			return func(c *chaos.Chaos) bool {
+				*runs++
+				*x = chaos.Input(c, "x", chaos.IntGen(0, 100))
+				return *x < 90
+			}
+		}
+		var runs, failing int
+		chaos.Check(&recorder{name: "TestRerun"}, property(&runs, &failing), chaos.Runs(1000), chaos.Shrinks(0))
+
+		t.Setenv("CHAOS_SEED", fmt.Sprintf("TestRerun#%d", runs-1))
+		var reruns, x int
+		r := &recorder{name: "TestRerun"}
+		assert.False(t, chaos.Check(r, property(&reruns, &x), chaos.Runs(1000), chaos.Shrinks(0)))
+		assert.Equal(t, 1, reruns)
+		assert.Equal(t, failing, x)
+		require.Len(t, r.errors, 1)
+		assert.Contains(t, r.errors[0], "property failed on case 1 ")
+	})
+
+	t.Run("ignores the seed variable of other checks", func(t *testing.T) {
+		t.Setenv("CHAOS_SEED", "TestOther#3")
+		runs := 0
+		chaos.Check(&recorder{name: "TestRerun"}, func(c *chaos.Chaos) bool {
+			runs++
+			return true
+		}, chaos.Runs(10))
+		assert.Equal(t, 10, runs)
+	})
+
+	t.Run("seed option", func(t *testing.T) {
+		r := &recorder{name: "TestName"}
+		chaos.Check(r, func(c *chaos.Chaos) bool { return false }, chaos.Seed("custom"))
+		require.Len(t, r.errors, 1)
+		assert.Contains(t, r.errors[0], `seed "custom#0"`)
+	})
+
+	t.Run("input outside of check", func(t *testing.T) {
+		c1, c2 := chaos.New(t.Name()), chaos.New(t.Name())
+		assert.Equal(t, chaos.IntGen(0, 100).Generate(c1), chaos.Input(c2, "x", chaos.IntGen(0, 100)))
+	})
+}
@@ -0,0 +1,523 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/types"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/raphoester/chaos/internal/tags"
+)
+
+// builder generates the function returning random values of one struct type.
+type builder struct {
+	g *generator
+	// self is the struct type of the function.
+	self *types.Named
+	// vars counts the local variables, so nested ones get distinct names.
+	vars int
+}
+
+// builder generates the function returning random values of the struct type named, honoring
+// the chaos tags of its fields. Unlike chaos.Fill, it also sets unexported fields.
+func (g *generator) builder(named *types.Named, st *types.Struct) error {
+	name := named.Obj().Name()
+	if named.TypeParams().Len() > 0 {
+		return errors.Join(ErrUnsupportedType, fmt.Errorf("%s is generic", name))
+	}
+	random := identifier("random", name)
+	b := &builder{g: g, self: named}
+
+	article := "a"
+	if strings.ContainsRune("AEIOUaeiou", rune(name[0])) {
+		article = "an"
+	}
+	g.printf("\n// %s returns %s %s with random fields, following their chaos tags.\n", random, article, name)
+	g.printf("func %s(c *chaos.Chaos) %s {\n", random, name)
+	g.printf("var v %s\n", name)
+	if err := b.fields("v", st, name); err != nil {
+		return err
+	}
+	g.printf("return v\n")
+	g.printf("}\n")
+	return nil
+}
+
+// fields generates the statements setting the fields of lhs, a struct named name.
+func (b *builder) fields(lhs string, st *types.Struct, name string) error {
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if field.Name() == "_" {
+			continue
+		}
+		var tag *tags.Options
+		if raw, ok := reflect.StructTag(st.Tag(i)).Lookup(tags.Name); ok {
+			var err error
+			if tag, err = parseTag(raw); err != nil {
+				return errors.Join(ErrInvalidTag, fmt.Errorf("field %s.%s: %w", name, field.Name(), err))
+			}
+		}
+		v := value{
+			lhs:  lhs + "." + field.Name(),
+			typ:  field.Type(),
+			tag:  tag,
+			name: name + "." + field.Name(),
+		}
+		if err := b.assign(v, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// value is a value set by the generated code.
+type value struct {
+	// lhs is the expression the value is assigned to.
+	lhs string
+	typ types.Type
+	tag *tags.Options
+	// name is the name of the field holding the value, for unique scopes and error messages.
+	name string
+}
+
+func (v value) errorf(format string, args ...any) error {
+	return errors.Join(ErrInvalidTag, fmt.Errorf("field %s: %s", v.name, fmt.Sprintf(format, args...)))
+}
+
+// with returns the value assigned to lhs, of type t, following tag.
+func (v value) with(lhs string, t types.Type, tag *tags.Options) value {
+	return value{lhs: lhs, typ: t, tag: tag, name: v.name}
+}
+
+// assign generates the statements setting v to a random value.
+// admitted is set for the items of containers already admitted by the size budget.
+func (b *builder) assign(v value, admitted bool) error {
+	g := b.g
+	if v.tag != nil && v.tag.Skip {
+		return nil
+	}
+	if v.tag != nil && v.tag.NilRatio != nil {
+		if !nillable(v.typ) {
+			return v.errorf("nil does not apply to %s", g.typeString(v.typ))
+		}
+		g.printf("if c.Float64(1) >= %s {\n", strconv.FormatFloat(*v.tag.NilRatio, 'g', -1, 64))
+		tag := *v.tag
+		tag.NilRatio = nil
+		if err := b.assign(v.with(v.lhs, v.typ, &tag), admitted); err != nil {
+			return err
+		}
+		g.printf("}\n")
+		return nil
+	}
+	if v.tag.HasLength() && !counted(v.typ) {
+		return v.errorf("len, minlen and maxlen do not apply to %s", g.typeString(v.typ))
+	}
+
+	// Containers leading back to the struct of the function, like the children of a tree,
+	// are generated with half the size of the chaos, and left nil with probability 1/(size+1),
+	// like chaos.Recursive does.
+	if !admitted && nillable(v.typ) && b.reaches(v.typ, map[*types.Named]bool{}) {
+		typ := g.typeString(v.typ)
+		x := b.newVar("x")
+		g.printf("if size := c.Size(); size > 0 && c.Int(size) != 0 {\n")
+		g.printf("%s = chaos.Resize(chaos.GenFunc[%s](func(c *chaos.Chaos) %s {\n", v.lhs, typ, typ)
+		g.printf("var %s %s\n", x, typ)
+		if err := b.assign(v.with(x, v.typ, v.tag), true); err != nil {
+			return err
+		}
+		g.printf("return %s\n", x)
+		g.printf("}), size/2).Generate(c)\n")
+		g.printf("}\n")
+		return nil
+	}
+
+	if named, ok := v.typ.(*types.Named); ok && b.opaque(named, v.tag) {
+		return b.leaf(v)
+	}
+	switch t := v.typ.Underlying().(type) {
+	case *types.Pointer:
+		x := b.newVar("x")
+		g.printf("{\n")
+		g.printf("var %s %s\n", x, g.typeString(t.Elem()))
+		if err := b.assign(v.with(x, t.Elem(), v.tag.Pointee()), admitted); err != nil {
+			return err
+		}
+		g.printf("%s = &%s\n", v.lhs, x)
+		g.printf("}\n")
+	case *types.Slice:
+		n, i := b.newVar("n"), b.newVar("i")
+		lo, hi := v.tag.LengthRange(tags.MinLen, tags.MaxLen)
+		g.printf("{\n")
+		g.printf("%s := %s\n", n, intBetween(lo, hi))
+		g.printf("%s = make(%s, %s)\n", v.lhs, g.typeString(v.typ), n)
+		g.printf("for %s := range %s {\n", i, v.lhs)
+		if err := b.assign(v.with(v.lhs+"["+i+"]", t.Elem(), v.tag.Element()), true); err != nil {
+			return err
+		}
+		g.printf("}\n")
+		g.printf("}\n")
+	case *types.Array:
+		i := b.newVar("i")
+		g.printf("for %s := range %s {\n", i, v.lhs)
+		if err := b.assign(v.with(v.lhs+"["+i+"]", t.Elem(), v.tag.Element()), admitted); err != nil {
+			return err
+		}
+		g.printf("}\n")
+	case *types.Map:
+		n, i, key, elem := b.newVar("n"), b.newVar("i"), b.newVar("k"), b.newVar("e")
+		lo, hi := v.tag.LengthRange(tags.MinLen, tags.MaxLen)
+		g.printf("{\n")
+		g.printf("%s := %s\n", n, intBetween(lo, hi))
+		g.printf("%s = make(%s, %s)\n", v.lhs, g.typeString(v.typ), n)
+		g.printf("for %s := 0; %s < %s; %s++ {\n", i, i, n, i)
+		g.printf("var %s %s\n", key, g.typeString(t.Key()))
+		if err := b.assign(v.with(key, t.Key(), nil), true); err != nil {
+			return err
+		}
+		g.printf("var %s %s\n", elem, g.typeString(t.Elem()))
+		if err := b.assign(v.with(elem, t.Elem(), v.tag.Element()), true); err != nil {
+			return err
+		}
+		g.printf("%s[%s] = %s\n", v.lhs, key, elem)
+		g.printf("}\n")
+		g.printf("}\n")
+	case *types.Struct:
+		if v.tag != nil && (v.tag.SetsValue() || v.tag.Unique) {
+			return v.errorf("options do not apply to %s", g.typeString(v.typ))
+		}
+		return b.fields(v.lhs, t, g.typeString(v.typ))
+	case *types.Basic:
+		return b.leaf(v)
+	default:
+		// Like chaos.Fill, interfaces, channels and functions are left untouched.
+	}
+	return nil
+}
+
+// opaque reports whether values of the named type are generated as a whole, by a function
+// generated by chaosgen or by chaos.Arbitrary, instead of from their underlying type.
+// Named types are only expanded when the options set their values.
+func (b *builder) opaque(named *types.Named, tag *tags.Options) bool {
+	if special(named) != "" {
+		return true
+	}
+	if b.g.listed[named.Obj()] {
+		return !tag.SetsValue()
+	}
+	return !tag.SetsValue() && !tag.HasLength()
+}
+
+// leaf generates the statement setting v, a value that is not expanded.
+func (b *builder) leaf(v value) error {
+	g := b.g
+	expr, err := b.leafExpr(v)
+	if err != nil {
+		return err
+	}
+	if v.tag == nil || !v.tag.Unique {
+		g.printf("%s = %s\n", v.lhs, expr)
+		return nil
+	}
+
+	if _, ok := v.typ.Underlying().(*types.Struct); ok && special(v.typ) != "time.Time" || !types.Comparable(v.typ) {
+		return v.errorf("unique does not apply to %s", g.typeString(v.typ))
+	}
+	typ := g.typeString(v.typ)
+	// The kind of the values is their reflect type, so they are unique across Fill calls too.
+	kind := types.TypeString(v.typ, func(p *types.Package) string { return p.Name() })
+	g.printf("{\n")
+	g.printf("value, err := chaos.UniqueValue(c.UniqueScope(%q), %q, func(c *chaos.Chaos) %s {\n", v.name, kind, typ)
+	g.printf("return %s\n", expr)
+	g.printf("})\n")
+	g.printf("if err != nil {\n")
+	g.printf("panic(err)\n")
+	g.printf("}\n")
+	g.printf("%s = value\n", v.lhs)
+	g.printf("}\n")
+	return nil
+}
+
+// leafExpr returns the expression generating v.
+func (b *builder) leafExpr(v value) (string, error) {
+	g := b.g
+	tag := v.tag
+	if tag == nil {
+		tag = &tags.Options{}
+	}
+
+	switch special(v.typ) {
+	case "time.Time":
+		if err := checkOptions(v, "min", "max"); err != nil {
+			return "", err
+		}
+		if tag.Min == nil && tag.Max == nil {
+			return "c.Time()", nil
+		}
+		lo, hi, err := tag.TimeBounds()
+		if err != nil {
+			return "", v.errorf("%v", err)
+		}
+		g.imports["time"] = true
+		return fmt.Sprintf("c.TimeBetween(time.Unix(%d, %d), time.Unix(%d, %d))",
+			lo.Unix(), lo.Nanosecond(), hi.Unix(), hi.Nanosecond()), nil
+	case "time.Duration":
+		if err := checkOptions(v, "min", "max", "oneof"); err != nil {
+			return "", err
+		}
+		if tag.Oneof != nil {
+			values := make([]string, len(tag.Oneof))
+			for i, raw := range tag.Oneof {
+				d, err := tags.ParseDuration(raw)
+				if err != nil {
+					return "", v.errorf("oneof: %v", err)
+				}
+				values[i] = strconv.FormatInt(int64(d), 10)
+			}
+			g.imports["time"] = true
+			return fmt.Sprintf("chaos.Elements[time.Duration](%s).Generate(c)", strings.Join(values, ", ")), nil
+		}
+		if tag.Min == nil && tag.Max == nil {
+			return fmt.Sprintf("c.Duration(%d)", tags.MaxDuration), nil
+		}
+		lo, hi, err := tag.DurationBounds()
+		if err != nil {
+			return "", v.errorf("%v", err)
+		}
+		return fmt.Sprintf("c.DurationBetween(%d, %d)", lo, hi), nil
+	case "uuid.UUID":
+		if err := checkOptions(v); err != nil {
+			return "", err
+		}
+		return "c.UUID()", nil
+	}
+
+	typ := g.typeString(v.typ)
+	if named, ok := v.typ.(*types.Named); ok && b.opaque(named, v.tag) {
+		if b.g.listed[named.Obj()] {
+			return identifier("random", named.Obj().Name()) + "(c)", nil
+		}
+		return fmt.Sprintf("chaos.Arbitrary[%s]().Generate(c)", typ), nil
+	}
+
+	basic, ok := v.typ.Underlying().(*types.Basic)
+	if !ok {
+		return "", v.errorf("options do not apply to %s", typ)
+	}
+	info := basic.Info()
+	// convert converts expr, of the basic type kind, to the type of v.
+	convert := func(expr string, kind types.BasicKind) string {
+		if types.Identical(v.typ, types.Typ[kind]) {
+			return expr
+		}
+		return typ + "(" + expr + ")"
+	}
+	if tag.Oneof != nil {
+		values := make([]string, len(tag.Oneof))
+		for i, raw := range tag.Oneof {
+			literal, err := basicLiteral(basic, raw)
+			if err != nil {
+				return "", v.errorf("oneof: %v", err)
+			}
+			values[i] = literal
+		}
+		return fmt.Sprintf("chaos.Elements[%s](%s).Generate(c)", typ, strings.Join(values, ", ")), nil
+	}
+
+	switch {
+	case info&types.IsString != 0:
+		if err := checkOptions(v, "len", "minlen", "maxlen", "regex", "charset", "faker"); err != nil {
+			return "", err
+		}
+		switch {
+		case tag.Regex != nil:
+			return convert(fmt.Sprintf("chaos.RegexGen(%s).Generate(c)", strconv.Quote(*tag.Regex)), types.String), nil
+		case tag.Faker != nil:
+			return convert(fmt.Sprintf("chaos.FakerGen(%q).Generate(c)", *tag.Faker), types.String), nil
+		}
+		length := intBetween(tag.LengthRange(tags.StringLength, tags.StringLength))
+		if tag.Charset != nil {
+			return convert(fmt.Sprintf("c.StringFrom(%s, %s)", strconv.Quote(*tag.Charset), length), types.String), nil
+		}
+		return convert(fmt.Sprintf("c.String(%s)", length), types.String), nil
+	case info&types.IsBoolean != 0:
+		if err := checkOptions(v); err != nil {
+			return "", err
+		}
+		return convert("c.Int(1) == 1", types.Bool), nil
+	case info&types.IsInteger != 0:
+		if err := checkOptions(v, "min", "max"); err != nil {
+			return "", err
+		}
+		if info&types.IsUnsigned != 0 {
+			lo, hi, err := tag.UintBounds(bits(basic))
+			if err != nil {
+				return "", v.errorf("%v", err)
+			}
+			return convert(fmt.Sprintf("c.Uint64Between(%d, %d)", lo, hi), types.Uint64), nil
+		}
+		lo, hi, err := tag.IntBounds(bits(basic))
+		if err != nil {
+			return "", v.errorf("%v", err)
+		}
+		return convert(fmt.Sprintf("c.Int64Between(%d, %d)", lo, hi), types.Int64), nil
+	case info&types.IsFloat != 0:
+		if err := checkOptions(v, "min", "max"); err != nil {
+			return "", err
+		}
+		lo, hi, err := tag.FloatBounds(bits(basic))
+		if err != nil {
+			return "", v.errorf("%v", err)
+		}
+		return convert(fmt.Sprintf("c.Float64Between(%s, %s)",
+			strconv.FormatFloat(lo, 'g', -1, 64), strconv.FormatFloat(hi, 'g', -1, 64)), types.Float64), nil
+	case info&types.IsComplex != 0:
+		if err := checkOptions(v); err != nil {
+			return "", err
+		}
+		return convert(fmt.Sprintf("complex(c.Float64Between(-%g, %g), c.Float64Between(-%g, %g))",
+			tags.MaxFloat, tags.MaxFloat, tags.MaxFloat, tags.MaxFloat), types.Complex128), nil
+	}
+	return "", errors.Join(ErrUnsupportedType, fmt.Errorf("field %s: %s", v.name, typ))
+}
+
+// intBetween returns the expression generating an int between lo and hi.
+func intBetween(lo, hi int) string {
+	if lo == hi {
+		return strconv.Itoa(lo)
+	}
+	return fmt.Sprintf("c.IntBetween(%d, %d)", lo, hi)
+}
+
+// checkOptions returns an error if the options of v set its value with other options than allowed.
+func checkOptions(v value, allowed ...string) error {
+	if v.tag == nil {
+		return nil
+	}
+	options := []struct {
+		name string
+		set  bool
+	}{
+		{"min", v.tag.Min != nil},
+		{"max", v.tag.Max != nil},
+		{"len", v.tag.Length != nil},
+		{"minlen", v.tag.MinLen != nil},
+		{"maxlen", v.tag.MaxLen != nil},
+		{"regex", v.tag.Regex != nil},
+		{"charset", v.tag.Charset != nil},
+		{"faker", v.tag.Faker != nil},
+		{"oneof", v.tag.Oneof != nil},
+	}
+	for _, option := range options {
+		if option.set && !slices.Contains(allowed, option.name) {
+			return v.errorf("%s does not apply to %s", option.name, types.TypeString(v.typ, nil))
+		}
+	}
+	return nil
+}
+
+// reaches reports whether values of type t may contain values of the struct of the function,
+// through the fields of the types generated by chaosgen.
+func (b *builder) reaches(t types.Type, seen map[*types.Named]bool) bool {
+	switch t := t.(type) {
+	case *types.Named:
+		if t.Obj() == b.self.Obj() {
+			return true
+		}
+		if !b.g.listed[t.Obj()] || seen[t] {
+			return false
+		}
+		seen[t] = true
+		return b.reaches(t.Underlying(), seen)
+	case *types.Pointer:
+		return b.reaches(t.Elem(), seen)
+	case *types.Slice:
+		return b.reaches(t.Elem(), seen)
+	case *types.Array:
+		return b.reaches(t.Elem(), seen)
+	case *types.Map:
+		return b.reaches(t.Key(), seen) || b.reaches(t.Elem(), seen)
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if b.reaches(t.Field(i).Type(), seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// newVar returns a new local variable name starting with prefix.
+func (b *builder) newVar(prefix string) string {
+	b.vars++
+	return prefix + strconv.Itoa(b.vars)
+}
+
+// special returns the name of the types generated by dedicated methods of Chaos, or "".
+func special(t types.Type) string {
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return ""
+	}
+	switch name := named.Obj().Pkg().Path() + "." + named.Obj().Name(); name {
+	case "time.Time", "time.Duration":
+		return name
+	case "github.com/google/uuid.UUID":
+		return "uuid.UUID"
+	}
+	return ""
+}
+
+// nillable reports whether values of type t can be nil.
+func nillable(t types.Type) bool {
+	switch t.Underlying().(type) {
+	case *types.Pointer, *types.Slice, *types.Map:
+		return true
+	}
+	return false
+}
+
+// counted reports whether length options apply to values of type t, through pointers.
+func counted(t types.Type) bool {
+	for {
+		switch u := t.Underlying().(type) {
+		case *types.Pointer:
+			t = u.Elem()
+		case *types.Slice, *types.Map:
+			return true
+		case *types.Basic:
+			return u.Info()&types.IsString != 0
+		default:
+			return false
+		}
+	}
+}
+
+// basicLiteral returns the Go literal of raw, a value of type t.
+func basicLiteral(t *types.Basic, raw string) (string, error) {
+	info := t.Info()
+	switch {
+	case info&types.IsString != 0:
+		return strconv.Quote(raw), nil
+	case info&types.IsBoolean != 0:
+		b, err := strconv.ParseBool(raw)
+		return strconv.FormatBool(b), err
+	case info&types.IsUnsigned != 0:
+		u, err := tags.ParseUint(raw, bits(t))
+		return strconv.FormatUint(u, 10), err
+	case info&types.IsInteger != 0:
+		i, err := tags.ParseInt(raw, bits(t))
+		return strconv.FormatInt(i, 10), err
+	case info&types.IsFloat != 0:
+		_, err := tags.ParseFloat(raw, bits(t))
+		return raw, err
+	}
+	return "", fmt.Errorf("oneof does not apply to %s", t)
+}
+
+// bits returns the size of the values of the basic type t, in bits.
+func bits(t *types.Basic) int {
+	return int(types.SizesFor("gc", "amd64").Sizeof(t)) * 8
+}
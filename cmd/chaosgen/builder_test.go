@@ -0,0 +1,176 @@
+package main
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/raphoester/chaos"
+	"github.com/raphoester/chaos/cmd/chaosgen/testdata/shop"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestBuilder(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		o1 := shop.RandomOrder(chaos.New(t.Name()))
+		o2 := shop.RandomOrder(chaos.New(t.Name()))
+		assert.Equal(t, o1, o2)
+	})
+
+	t.Run("matches the generated file", func(t *testing.T) {
+		src, err := generate("testdata/shop", []string{"Order", "Customer", "Category"}, []string{"-type", "Order,Customer,Category", "-output", "order_chaos.go"})
+		require.NoError(t, err)
+		want, err := os.ReadFile("testdata/shop/order_chaos.go")
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(src), "run go generate in testdata/shop")
+	})
+
+	t.Run("honors tags", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		statuses := []shop.Status{shop.StatusPending, shop.StatusPaid, shop.StatusShipped, shop.StatusCancelled}
+		codes := make(map[string]bool)
+		for i := 0; i < 100; i++ {
+			o := shop.RandomOrder(c)
+			assert.Regexp(t, `^ORD-\d{6}$`, o.Reference)
+			assert.Contains(t, statuses, o.Status)
+			assert.True(t, len(o.Lines) >= 1 && len(o.Lines) <= 3, len(o.Lines))
+			assert.True(t, o.Total >= 1 && o.Total <= 100000, o.Total)
+			if o.Discount != nil {
+				assert.True(t, *o.Discount >= 0 && *o.Discount <= 0.3, *o.Discount)
+			}
+			assert.Len(t, o.Notes, 2)
+			for _, v := range o.Notes {
+				assert.Regexp(t, `^[abc]*$`, v)
+			}
+			assert.Equal(t, 2024, o.PlacedAt.UTC().Year())
+			assert.True(t, o.Timeout >= time.Second && o.Timeout <= time.Minute, o.Timeout)
+			assert.Contains(t, []string{"web", "store", "phone"}, o.Channel)
+			assert.Regexp(t, `^[A-F]{8}$`, o.Code)
+			assert.False(t, codes[o.Code], "duplicate code %s", o.Code)
+			codes[o.Code] = true
+			assert.Contains(t, []shop.Kind{"urgent", "normal"}, o.Priority)
+			assert.Empty(t, o.Internal)
+			assert.True(t, o.Customer.Age >= 18 && o.Customer.Age <= 99, o.Customer.Age)
+			assert.Contains(t, o.Customer.Email, "@")
+		}
+	})
+
+	t.Run("spans wide integer ranges", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		var highCounter, lowBalance, highBalance bool
+		for i := 0; i < 100; i++ {
+			o := shop.RandomOrder(c)
+			highCounter = highCounter || o.Counter > math.MaxInt64
+			lowBalance = lowBalance || o.Balance < -math.MaxInt64/2
+			highBalance = highBalance || o.Balance > math.MaxInt64/2
+			assert.True(t, o.Balance >= -9e18 && o.Balance <= 9e18, o.Balance)
+		}
+		assert.True(t, highCounter)
+		assert.True(t, lowBalance)
+		assert.True(t, highBalance)
+	})
+
+	t.Run("sets unexported fields", func(t *testing.T) {
+		o := shop.RandomOrder(chaos.New(t.Name()))
+		assert.Len(t, o.Secret(), 4)
+	})
+
+	t.Run("bounds recursive types", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		var depth func(cat *shop.Category) int
+		depth = func(cat *shop.Category) int {
+			d := 0
+			if cat.Parent != nil {
+				d = depth(cat.Parent)
+			}
+			for _, child := range cat.Children {
+				d = max(d, depth(child))
+			}
+			return d + 1
+		}
+		for i := 0; i < 100; i++ {
+			cat := shop.RandomCategory(c)
+			assert.LessOrEqual(t, depth(&cat), 6)
+			assert.LessOrEqual(t, len(cat.Children), 3)
+		}
+	})
+
+	t.Run("reads tags with the grammar of Fill", func(t *testing.T) {
+		dir := writePackage(t, map[string]string{"p.go": "package p\n\ntype T struct {\n\tA uint8 `chaos:\"keep,min=0x10,max=0xff\"`\n\tB int `chaos:\"oneof=0b1 -0o7\"`\n}\n"})
+		out, err := generate(dir, []string{"T"}, nil)
+		require.NoError(t, err)
+		assert.Contains(t, string(out), "c.Uint64Between(16, 255)")
+		assert.Contains(t, string(out), "chaos.Elements[int](1, -7)")
+	})
+
+	t.Run("returns error for invalid tags", func(t *testing.T) {
+		for name, field := range map[string]string{
+			"unknown option":   "A string `chaos:\"size=3\"`",
+			"min on string":    "A string `chaos:\"min=3\"`",
+			"nil on value":     "A int `chaos:\"nil=0.5\"`",
+			"unique on struct": "A struct{ B int } `chaos:\"unique\"`",
+			"len on int":       "A int `chaos:\"len=3\"`",
+		} {
+			t.Run(name, func(t *testing.T) {
+				dir := writePackage(t, map[string]string{"p.go": "package p\n\ntype T struct {\n\t" + field + "\n}\n"})
+				_, err := generate(dir, []string{"T"}, nil)
+				assert.ErrorIs(t, err, ErrInvalidTag)
+			})
+		}
+	})
+
+	t.Run("names the first option that does not apply", func(t *testing.T) {
+		dir := writePackage(t, map[string]string{"p.go": "package p\n\ntype T struct {\n\tA bool `chaos:\"max=1,min=0,charset=ab\"`\n}\n"})
+		for i := 0; i < 20; i++ {
+			_, err := generate(dir, []string{"T"}, nil)
+			require.ErrorIs(t, err, ErrInvalidTag)
+			assert.Contains(t, err.Error(), "min does not apply to bool")
+		}
+	})
+
+	t.Run("returns error for unsupported types", func(t *testing.T) {
+		dir := writePackage(t, map[string]string{"p.go": "package p\n\ntype F func()\n"})
+		_, err := generate(dir, []string{"F"}, nil)
+		assert.ErrorIs(t, err, ErrUnsupportedType)
+	})
+
+	t.Run("regenerates packages using their builders", func(t *testing.T) {
+		dir := writePackage(t, map[string]string{
+			"p.go": "package p\n\nimport \"github.com/raphoester/chaos\"\n\ntype T struct {\n\tA int\n}\n\n" +
+				"func Sample(c *chaos.Chaos) int {\n\treturn RandomT(c).A\n}\n",
+			// The generated file is stale: field B was removed from T.
+			"t_chaos.go": "// Code generated by \"chaosgen -type T\"; DO NOT EDIT.\n\npackage p\n\n" +
+				"import \"github.com/raphoester/chaos\"\n\nfunc RandomT(c *chaos.Chaos) T {\n\treturn T{B: 1}\n}\n",
+		})
+		src, err := generate(dir, []string{"T"}, []string{"-type", "T"})
+		require.NoError(t, err)
+		assert.Contains(t, string(src), "func RandomT(c *chaos.Chaos) T {")
+		assert.Contains(t, string(src), "v.A = ")
+	})
+
+	t.Run("returns error for packages that do not type-check", func(t *testing.T) {
+		dir := writePackage(t, map[string]string{"p.go": "package p\n\ntype T struct {\n\tA Missing\n}\n"})
+		_, err := generate(dir, []string{"T"}, nil)
+		var loadErr packages.Error
+		require.ErrorAs(t, err, &loadErr)
+		assert.Equal(t, packages.TypeError, loadErr.Kind)
+		assert.Contains(t, loadErr.Msg, "Missing")
+	})
+}
+
+// writePackage writes a package made of files in a temporary directory of the module,
+// so the package can import chaos.
+func writePackage(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("testdata", "tmp")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	for name, src := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644))
+	}
+	return dir
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/types"
+	"sort"
+)
+
+// enum generates the generator of the constants of named, registered with chaos.Enum.
+func (g *generator) enum(named *types.Named) error {
+	name := named.Obj().Name()
+	if _, ok := named.Underlying().(*types.Basic); !ok {
+		return errors.Join(ErrUnsupportedType, fmt.Errorf("%s is not a basic type", name))
+	}
+	consts := enumConstants(g.pkg, named)
+	if len(consts) == 0 {
+		return errors.Join(ErrUnsupportedType, fmt.Errorf("%s has no constants", name))
+	}
+
+	gen, random := "chaos"+capitalize(name), identifier("random", name)
+	g.printf("\n// %s generates the constants of %s. Declaring it registers them with chaos.Enum.\n", gen, name)
+	g.printf("var %s = chaos.Enum(\n", gen)
+	for _, c := range consts {
+		g.printf("\t%s,\n", c.Name())
+	}
+	g.printf(")\n")
+	g.printf("\n// %s returns one of the constants of %s.\n", random, name)
+	g.printf("func %s(c *chaos.Chaos) %s {\n", random, name)
+	g.printf("\treturn %s.Generate(c)\n", gen)
+	g.printf("}\n")
+	return nil
+}
+
+// enumConstants returns the constants of type named declared in pkg, in declaration order.
+// Constants sharing the value of a previous one, like aliases, are skipped.
+func enumConstants(pkg *types.Package, named *types.Named) []*types.Const {
+	var consts []*types.Const
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if ok && name != "_" && types.Identical(c.Type(), named) {
+			consts = append(consts, c)
+		}
+	}
+	sort.Slice(consts, func(i, j int) bool {
+		return consts[i].Pos() < consts[j].Pos()
+	})
+
+	seen := make(map[string]bool)
+	unique := consts[:0]
+	for _, c := range consts {
+		if value := c.Val().ExactString(); !seen[value] {
+			seen[value] = true
+			unique = append(unique, c)
+		}
+	}
+	return unique
+}
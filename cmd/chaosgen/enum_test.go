@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/raphoester/chaos"
+	"github.com/raphoester/chaos/cmd/chaosgen/testdata/shop"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnum(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		src1, err1 := generate("testdata/shop", []string{"Status"}, nil)
+		src2, err2 := generate("testdata/shop", []string{"Status"}, nil)
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.Equal(t, src1, src2)
+	})
+
+	t.Run("matches the generated file", func(t *testing.T) {
+		src, err := generate("testdata/shop", []string{"Status", "Kind", "priority"}, []string{"-type", "Status,Kind,priority"})
+		require.NoError(t, err)
+		want, err := os.ReadFile("testdata/shop/status_chaos.go")
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(src), "run go generate in testdata/shop")
+	})
+
+	t.Run("lists distinct constants in declaration order", func(t *testing.T) {
+		src, err := generate("testdata/shop", []string{"Status"}, nil)
+		require.NoError(t, err)
+		assert.Regexp(t, `(?s)StatusPending,\s+StatusPaid,\s+StatusShipped,\s+StatusCancelled,\s+\)`, string(src))
+		assert.NotContains(t, string(src), "StatusDefault")
+		assert.NotContains(t, string(src), "MaxItems")
+	})
+
+	t.Run("generated functions return constants", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		statuses := []shop.Status{shop.StatusPending, shop.StatusPaid, shop.StatusShipped, shop.StatusCancelled}
+		for i := 0; i < 100; i++ {
+			assert.Contains(t, statuses, shop.RandomStatus(c))
+			assert.Contains(t, []shop.Kind{shop.KindBook, shop.KindMusic, shop.KindGame}, shop.RandomKind(c))
+		}
+	})
+
+	t.Run("fill generates constants", func(t *testing.T) {
+		type order struct {
+			Status shop.Status
+			Kinds  []shop.Kind
+		}
+		c := chaos.New(t.Name())
+		for i := 0; i < 100; i++ {
+			var o order
+			require.NoError(t, c.Fill(&o))
+			assert.Contains(t, []shop.Status{shop.StatusPending, shop.StatusPaid, shop.StatusShipped, shop.StatusCancelled}, o.Status)
+			for _, kind := range o.Kinds {
+				assert.Contains(t, []shop.Kind{shop.KindBook, shop.KindMusic, shop.KindGame}, kind)
+			}
+		}
+	})
+
+	t.Run("returns error for unknown types", func(t *testing.T) {
+		_, err := generate("testdata/shop", []string{"Color"}, nil)
+		assert.ErrorIs(t, err, ErrUnknownType)
+	})
+
+	t.Run("returns error for types without constants", func(t *testing.T) {
+		_, err := generate("testdata/shop", []string{"Note"}, nil)
+		assert.ErrorIs(t, err, ErrUnsupportedType)
+	})
+}
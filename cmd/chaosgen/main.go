@@ -0,0 +1,308 @@
+// Chaosgen generates typed chaos generators for the types of a Go package.
+//
+// Usage:
+//
+//	chaosgen -type User,Status [-output file] [dir]
+//
+// For each struct type, it generates a function returning a value with random fields,
+// like RandomUser(c *chaos.Chaos) User. The function honors the chaos tags of the fields,
+// like chaos.Fill, but does not use reflection: it is type-checked and fast. It also sets
+// unexported fields. Fields of the listed types are generated by their generated functions,
+// and fields of other named types by chaos.Arbitrary, so registered generators are used.
+// Fields leading back to the struct, like the children of a tree, are bounded by the size
+// of the chaos, like chaos.Recursive.
+//
+// For each type with constants, like an enumeration, it generates a function returning
+// one of the constants, like RandomStatus(c *chaos.Chaos) Status, and registers the
+// constants with chaos.Enum so Fill only generates valid values.
+//
+// It is meant to be used with go generate, next to the type declarations:
+//
+//	//go:generate go run github.com/raphoester/chaos/cmd/chaosgen -type User,Status
+//
+// By default, the code is written to <type>_chaos.go, named after the first type, in the
+// directory of the package.
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+var (
+	ErrUnknownType     = errors.New("unknown type")
+	ErrUnsupportedType = errors.New("unsupported type")
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of type names; required")
+	output := flag.String("output", "", "output file name; default <dir>/<type>_chaos.go")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: chaosgen -type T[,T...] [-output file] [dir]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *typeNames == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+	names := strings.Split(*typeNames, ",")
+
+	src, err := generate(dir, names, os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "chaosgen: %v\n", err)
+		os.Exit(1)
+	}
+	if *output == "" {
+		*output = filepath.Join(dir, strings.ToLower(names[0])+"_chaos.go")
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "chaosgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// generate returns the formatted source of the generators of the named types of the package in dir.
+// args are the command line arguments, recorded in the header of the file.
+func generate(dir string, names []string, args []string) ([]byte, error) {
+	pkg, err := loadPackage(dir)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{
+		pkg:     pkg,
+		listed:  make(map[*types.TypeName]bool),
+		imports: map[string]bool{"github.com/raphoester/chaos": true},
+	}
+	var named []*types.Named
+	for _, name := range names {
+		obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			return nil, errors.Join(ErrUnknownType, fmt.Errorf("no type %s in package %s", name, pkg.Name()))
+		}
+		t, ok := obj.Type().(*types.Named)
+		if !ok {
+			return nil, errors.Join(ErrUnsupportedType, fmt.Errorf("%s is an alias", name))
+		}
+		g.listed[obj] = true
+		named = append(named, t)
+	}
+
+	for _, t := range named {
+		var err error
+		switch u := t.Underlying().(type) {
+		case *types.Struct:
+			err = g.builder(t, u)
+		case *types.Basic:
+			err = g.enum(t)
+		default:
+			err = errors.Join(ErrUnsupportedType, fmt.Errorf("%s is neither a struct nor a basic type", t.Obj().Name()))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var file bytes.Buffer
+	fmt.Fprintf(&file, "// Code generated by \"chaosgen %s\"; DO NOT EDIT.\n\n", strings.Join(args, " "))
+	fmt.Fprintf(&file, "package %s\n\n", pkg.Name())
+	file.Write(g.importDecl())
+	file.Write(g.buf.Bytes())
+
+	src, err := format.Source(file.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return src, nil
+}
+
+// loadPackage loads and type-checks the package in dir with go/packages, so build tags,
+// cgo and modules are handled like by the go command.
+// Files generated by chaosgen are loaded empty, so stale generated code does not prevent
+// generating it again. The package may use the identifiers they declare, like the RandomUser
+// function of a type: type errors only caused by these identifiers are ignored.
+func loadPackage(dir string) (*types.Package, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	overlay, generated, err := generatedFiles(abs)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &packages.Config{
+		// Every package is type-checked from source, so loading does not depend on the export
+		// data format of the toolchain.
+		Mode:    packages.NeedName | packages.NeedSyntax | packages.NeedTypes | packages.NeedImports | packages.NeedDeps,
+		Dir:     dir,
+		Overlay: overlay,
+		// Only the declarations of the imported packages matter, so their function bodies are
+		// dropped to type-check them faster.
+		ParseFile: func(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
+			f, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+			if err != nil || filepath.Dir(filename) == abs {
+				return f, err
+			}
+			for _, decl := range f.Decls {
+				if fn, ok := decl.(*ast.FuncDecl); ok {
+					fn.Body = nil
+				}
+			}
+			return f, nil
+		},
+	}
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		return nil, fmt.Errorf("load package: %w", err)
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("load package: %d packages in %s", len(pkgs), dir)
+	}
+	for _, e := range pkgs[0].Errors {
+		if e.Kind == packages.TypeError && generated[strings.TrimPrefix(e.Msg, "undefined: ")] {
+			continue
+		}
+		return nil, fmt.Errorf("load package: %w", e)
+	}
+	return pkgs[0].Types, nil
+}
+
+// generatedFiles returns the files of dir generated by chaosgen, emptied down to their package
+// clause, and the names they declare at the top level.
+func generatedFiles(dir string) (map[string][]byte, map[string]bool, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, nil, err
+	}
+	overlay := make(map[string][]byte)
+	declared := make(map[string]bool)
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse package: %w", err)
+		}
+		if !isGenerated(f) {
+			continue
+		}
+		overlay[path] = []byte("package " + f.Name.Name + "\n")
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil {
+					declared[decl.Name.Name] = true
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						declared[spec.Name.Name] = true
+					case *ast.ValueSpec:
+						for _, name := range spec.Names {
+							declared[name.Name] = true
+						}
+					}
+				}
+			}
+		}
+	}
+	return overlay, declared, nil
+}
+
+// generator accumulates the generated code.
+type generator struct {
+	pkg *types.Package
+	// listed are the types code is generated for.
+	listed map[*types.TypeName]bool
+	// imports are the paths of the packages used by the generated code.
+	imports map[string]bool
+	buf     bytes.Buffer
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// typeString returns the name of t in the generated code, qualified outside of the package.
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		if p == g.pkg {
+			return ""
+		}
+		g.imports[p.Path()] = true
+		return p.Name()
+	})
+}
+
+// importDecl returns the import declaration of the generated code.
+// Standard packages come first, in their own group.
+func (g *generator) importDecl() []byte {
+	var paths []string
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	if len(paths) == 1 {
+		return []byte(fmt.Sprintf("import %q\n", paths[0]))
+	}
+	slices.SortFunc(paths, func(a, b string) int {
+		return cmp.Or(cmp.Compare(isThirdParty(a), isThirdParty(b)), cmp.Compare(a, b))
+	})
+	var decl bytes.Buffer
+	decl.WriteString("import (\n")
+	for i, path := range paths {
+		if i > 0 && isThirdParty(path) != isThirdParty(paths[i-1]) {
+			decl.WriteString("\n")
+		}
+		fmt.Fprintf(&decl, "%q\n", path)
+	}
+	decl.WriteString(")\n")
+	return decl.Bytes()
+}
+
+// isThirdParty returns 1 for the paths of packages outside of the standard library, and 0 otherwise.
+func isThirdParty(path string) int {
+	first, _, _ := strings.Cut(path, "/")
+	if strings.Contains(first, ".") {
+		return 1
+	}
+	return 0
+}
+
+// identifier returns prefix followed by name, exported like the type name is:
+// identifier("random", "Status") is "RandomStatus" and identifier("random", "status") is "randomStatus".
+func identifier(prefix, name string) string {
+	if token.IsExported(name) {
+		prefix = capitalize(prefix)
+	}
+	return prefix + capitalize(name)
+}
+
+// capitalize returns s with its first letter in upper case.
+func capitalize(s string) string {
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// isGenerated reports whether f was generated by chaosgen, so it is not read when generating it again.
+func isGenerated(f *ast.File) bool {
+	return len(f.Comments) > 0 && strings.HasPrefix(f.Comments[0].Text(), `Code generated by "chaosgen`)
+}
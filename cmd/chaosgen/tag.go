@@ -0,0 +1,19 @@
+package main
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/raphoester/chaos"
+	"github.com/raphoester/chaos/internal/tags"
+)
+
+var ErrInvalidTag = errors.New("invalid struct tag")
+
+// parseTag parses the chaos struct tag of a field, with the grammar and the fakers of chaos.Fill.
+// As the builders start from zero values, keep has no effect.
+func parseTag(tag string) (*tags.Options, error) {
+	return tags.Parse(tag, func(faker string) bool {
+		return slices.Contains(chaos.Fakers(), faker)
+	})
+}
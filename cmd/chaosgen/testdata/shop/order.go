@@ -0,0 +1,69 @@
+package shop
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/raphoester/chaos"
+)
+
+//go:generate go run github.com/raphoester/chaos/cmd/chaosgen -type Order,Customer,Category -output order_chaos.go
+
+// Order is an order of a customer.
+type Order struct {
+	ID        uuid.UUID
+	Reference string `chaos:"regex=^ORD-\\d{6}$"`
+	Customer  Customer
+	Status    Status
+	Lines     []Line            `chaos:"minlen=1,maxlen=3"`
+	Total     int64             `chaos:"min=1,max=100000"`
+	Discount  *float64          `chaos:"nil=0.5,min=0,max=0.3"`
+	Notes     map[string]string `chaos:"len=2,charset=abc"`
+	PlacedAt  time.Time         `chaos:"min=2024-01-01T00:00:00Z,max=2025-01-01T00:00:00Z"`
+	Timeout   time.Duration     `chaos:"min=1s,max=1m"`
+	Channel   string            `chaos:"oneof=web store phone"`
+	Code      string            `chaos:"unique,len=8,charset=ABCDEF"`
+	Priority  Kind              `chaos:"oneof=urgent normal"`
+	Internal  string            `chaos:"-"`
+	Flags     [2]bool
+	Level     uint8
+	Ratio     float32
+	Counter   uint64
+	Balance   int64  `chaos:"min=-9000000000000000000,max=9000000000000000000"`
+	secret    string `chaos:"len=4"`
+}
+
+// SampleOrders returns n random orders, using the builder generated by chaosgen.
+func SampleOrders(c *chaos.Chaos, n int) []Order {
+	orders := make([]Order, n)
+	for i := range orders {
+		orders[i] = RandomOrder(c)
+	}
+	return orders
+}
+
+// Secret returns the unexported field of the order.
+func (o Order) Secret() string {
+	return o.secret
+}
+
+// Customer is the customer of an order.
+type Customer struct {
+	Name  string `chaos:"faker=title"`
+	Email string `chaos:"faker=email"`
+	Age   int    `chaos:"min=18,max=99"`
+}
+
+// Line is a line of an order. It is generated by chaos.Arbitrary.
+type Line struct {
+	SKU      string `chaos:"len=6"`
+	Kind     Kind
+	Quantity uint `chaos:"min=1,max=5"`
+}
+
+// Category is a recursive type.
+type Category struct {
+	Name     string `chaos:"faker=word"`
+	Parent   *Category
+	Children []*Category `chaos:"maxlen=3"`
+}
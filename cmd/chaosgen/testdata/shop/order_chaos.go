@@ -0,0 +1,110 @@
+// Code generated by "chaosgen -type Order,Customer,Category -output order_chaos.go"; DO NOT EDIT.
+
+package shop
+
+import (
+	"time"
+
+	"github.com/raphoester/chaos"
+)
+
+// RandomOrder returns an Order with random fields, following their chaos tags.
+func RandomOrder(c *chaos.Chaos) Order {
+	var v Order
+	v.ID = c.UUID()
+	v.Reference = chaos.RegexGen("^ORD-\\d{6}$").Generate(c)
+	v.Customer = RandomCustomer(c)
+	v.Status = chaos.Arbitrary[Status]().Generate(c)
+	{
+		n1 := c.IntBetween(1, 3)
+		v.Lines = make([]Line, n1)
+		for i2 := range v.Lines {
+			v.Lines[i2] = chaos.Arbitrary[Line]().Generate(c)
+		}
+	}
+	v.Total = c.Int64Between(1, 100000)
+	if c.Float64(1) >= 0.5 {
+		{
+			var x3 float64
+			x3 = c.Float64Between(0, 0.3)
+			v.Discount = &x3
+		}
+	}
+	{
+		n4 := 2
+		v.Notes = make(map[string]string, n4)
+		for i5 := 0; i5 < n4; i5++ {
+			var k6 string
+			k6 = c.String(10)
+			var e7 string
+			e7 = c.StringFrom("abc", 10)
+			v.Notes[k6] = e7
+		}
+	}
+	v.PlacedAt = c.TimeBetween(time.Unix(1704067200, 0), time.Unix(1735689600, 0))
+	v.Timeout = c.DurationBetween(1000000000, 60000000000)
+	v.Channel = chaos.Elements[string]("web", "store", "phone").Generate(c)
+	{
+		value, err := chaos.UniqueValue(c.UniqueScope("Order.Code"), "string", func(c *chaos.Chaos) string {
+			return c.StringFrom("ABCDEF", 8)
+		})
+		if err != nil {
+			panic(err)
+		}
+		v.Code = value
+	}
+	v.Priority = chaos.Elements[Kind]("urgent", "normal").Generate(c)
+	for i8 := range v.Flags {
+		v.Flags[i8] = c.Int(1) == 1
+	}
+	v.Level = uint8(c.Uint64Between(0, 255))
+	v.Ratio = float32(c.Float64Between(-1e+06, 1e+06))
+	v.Counter = c.Uint64Between(0, 18446744073709551615)
+	v.Balance = c.Int64Between(-9000000000000000000, 9000000000000000000)
+	v.secret = c.String(4)
+	return v
+}
+
+// RandomCustomer returns a Customer with random fields, following their chaos tags.
+func RandomCustomer(c *chaos.Chaos) Customer {
+	var v Customer
+	v.Name = chaos.FakerGen("title").Generate(c)
+	v.Email = chaos.FakerGen("email").Generate(c)
+	v.Age = int(c.Int64Between(18, 99))
+	return v
+}
+
+// RandomCategory returns a Category with random fields, following their chaos tags.
+func RandomCategory(c *chaos.Chaos) Category {
+	var v Category
+	v.Name = chaos.FakerGen("word").Generate(c)
+	if size := c.Size(); size > 0 && c.Int(size) != 0 {
+		v.Parent = chaos.Resize(chaos.GenFunc[*Category](func(c *chaos.Chaos) *Category {
+			var x1 *Category
+			{
+				var x2 Category
+				x2 = RandomCategory(c)
+				x1 = &x2
+			}
+			return x1
+		}), size/2).Generate(c)
+	}
+	if size := c.Size(); size > 0 && c.Int(size) != 0 {
+		v.Children = chaos.Resize(chaos.GenFunc[[]*Category](func(c *chaos.Chaos) []*Category {
+			var x3 []*Category
+			{
+				n4 := c.IntBetween(1, 3)
+				x3 = make([]*Category, n4)
+				for i5 := range x3 {
+					{
+						var x6 Category
+						x6 = RandomCategory(c)
+						x3[i5] = &x6
+					}
+				}
+			}
+			return x3
+		}), size/2).Generate(c)
+	}
+	return v
+}
@@ -0,0 +1,40 @@
+// Package shop is a sample package for the tests of chaosgen.
+package shop
+
+//go:generate go run github.com/raphoester/chaos/cmd/chaosgen -type Status,Kind,priority
+
+// Status is the status of an order.
+type Status int
+
+const (
+	StatusPending Status = iota + 1
+	StatusPaid
+	StatusShipped
+	_
+	StatusCancelled
+
+	// StatusDefault is an alias of StatusPending.
+	StatusDefault = StatusPending
+)
+
+// Kind is the kind of a product.
+type Kind string
+
+const (
+	KindBook  Kind = "book"
+	KindMusic Kind = "music"
+	KindGame  Kind = "game"
+)
+
+// MaxItems is not a constant of an enumeration.
+const MaxItems = 10
+
+type priority uint8
+
+const (
+	priorityLow priority = iota
+	priorityHigh
+)
+
+// Note has no constants.
+type Note string
@@ -0,0 +1,41 @@
+// Code generated by "chaosgen -type Status,Kind,priority"; DO NOT EDIT.
+
+package shop
+
+import "github.com/raphoester/chaos"
+
+// chaosStatus generates the constants of Status. Declaring it registers them with chaos.Enum.
+var chaosStatus = chaos.Enum(
+	StatusPending,
+	StatusPaid,
+	StatusShipped,
+	StatusCancelled,
+)
+
+// RandomStatus returns one of the constants of Status.
+func RandomStatus(c *chaos.Chaos) Status {
+	return chaosStatus.Generate(c)
+}
+
+// chaosKind generates the constants of Kind. Declaring it registers them with chaos.Enum.
+var chaosKind = chaos.Enum(
+	KindBook,
+	KindMusic,
+	KindGame,
+)
+
+// RandomKind returns one of the constants of Kind.
+func RandomKind(c *chaos.Chaos) Kind {
+	return chaosKind.Generate(c)
+}
+
+// chaosPriority generates the constants of priority. Declaring it registers them with chaos.Enum.
+var chaosPriority = chaos.Enum(
+	priorityLow,
+	priorityHigh,
+)
+
+// randomPriority returns one of the constants of priority.
+func randomPriority(c *chaos.Chaos) priority {
+	return chaosPriority.Generate(c)
+}
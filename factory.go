@@ -0,0 +1,101 @@
+package chaos
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Factory builds fixtures of type T: values populated with defaults, optionally
+// customized by named traits and per-build overrides.
+// A factory is a Gen, so it can be used in combinators or to build associations
+// from the definition of another factory:
+//
+//	posts := chaos.NewFactory(c, func(c *chaos.Chaos, p *Post) {
+//		p.Author = users.Generate(c)
+//	})
+type Factory[T any] struct {
+	c      *Chaos
+	define func(c *Chaos, v *T)
+	traits map[string]func(c *Chaos, v *T)
+	with   []string
+}
+
+// NewFactory returns a factory building values of type T with c.
+// define sets the default values of the fields. If define is nil, values are populated by Fill,
+// and building panics if a struct tag of T is invalid.
+func NewFactory[T any](c *Chaos, define func(c *Chaos, v *T)) *Factory[T] {
+	return &Factory[T]{
+		c:      c,
+		define: define,
+		traits: make(map[string]func(c *Chaos, v *T)),
+	}
+}
+
+// Trait registers a named variation of the defaults, applied by With.
+// It returns the factory, so traits can be chained.
+func (f *Factory[T]) Trait(name string, apply func(c *Chaos, v *T)) *Factory[T] {
+	f.traits[name] = apply
+	return f
+}
+
+// With returns a factory applying the named traits, in order, after the defaults.
+// The traits of f are applied first, and traits registered on the returned factory are not
+// visible from f. It panics if a trait was not registered: like the definition of the
+// factory, trait names are known when writing the test.
+func (f *Factory[T]) With(traits ...string) *Factory[T] {
+	for _, name := range traits {
+		if _, ok := f.traits[name]; !ok {
+			panic(fmt.Sprintf("chaos: unknown trait %q", name))
+		}
+	}
+	derived := *f
+	derived.traits = maps.Clone(f.traits)
+	derived.with = append(slices.Clip(f.with), traits...)
+	return &derived
+}
+
+// Build returns a new value, with overrides applied in order after the defaults and traits.
+func (f *Factory[T]) Build(overrides ...func(v *T)) T {
+	return f.build(f.c, overrides)
+}
+
+// BuildN returns n new values, with overrides applied to each of them.
+func (f *Factory[T]) BuildN(n int, overrides ...func(v *T)) []T {
+	ret := make([]T, max(n, 0))
+	for i := range ret {
+		ret[i] = f.build(f.c, overrides)
+	}
+	return ret
+}
+
+// Generate builds a new value with c instead of the chaos of the factory.
+func (f *Factory[T]) Generate(c *Chaos) T {
+	return f.build(c, nil)
+}
+
+func (f *Factory[T]) build(c *Chaos, overrides []func(v *T)) T {
+	var v T
+	if f.define != nil {
+		f.define(c, &v)
+	} else if err := c.Fill(&v); err != nil {
+		panic(err)
+	}
+	for _, name := range f.with {
+		f.traits[name](c, &v)
+	}
+	for _, override := range overrides {
+		override(&v)
+	}
+	return v
+}
+
+// NewSequence returns a sequence of strings numbered from 1, like "user-1", "user-2"...
+// format must contain a single integer verb, like %d or %03d.
+func NewSequence(format string) *IDSequence[string] {
+	n := 0
+	return &IDSequence[string]{next: func() string {
+		n++
+		return fmt.Sprintf(format, n)
+	}}
+}
@@ -0,0 +1,107 @@
+package chaos_test
+
+import (
+	"testing"
+
+	"github.com/raphoester/chaos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type factoryUser struct {
+	Username string
+	Email    string
+	Admin    bool
+	Active   bool
+}
+
+type factoryPost struct {
+	Title  string
+	Author factoryUser
+}
+
+func newUserFactory(c *chaos.Chaos) *chaos.Factory[factoryUser] {
+	usernames := chaos.NewSequence("user-%d")
+	return chaos.NewFactory(c, func(c *chaos.Chaos, u *factoryUser) {
+		u.Username = usernames.Next()
+		u.Email = c.Email()
+		u.Active = true
+	}).Trait("admin", func(c *chaos.Chaos, u *factoryUser) {
+		u.Admin = true
+	}).Trait("inactive", func(c *chaos.Chaos, u *factoryUser) {
+		u.Active = false
+	})
+}
+
+func TestFactory(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		u1 := newUserFactory(chaos.New(t.Name())).BuildN(5)
+		u2 := newUserFactory(chaos.New(t.Name())).BuildN(5)
+		assert.Equal(t, u1, u2)
+	})
+
+	t.Run("applies defaults and sequences", func(t *testing.T) {
+		users := newUserFactory(chaos.New(t.Name()))
+		built := users.BuildN(3)
+		require.Len(t, built, 3)
+		for i, u := range built {
+			assert.Equal(t, []string{"user-1", "user-2", "user-3"}[i], u.Username)
+			assert.Contains(t, u.Email, "@")
+			assert.True(t, u.Active)
+			assert.False(t, u.Admin)
+		}
+	})
+
+	t.Run("applies overrides after defaults", func(t *testing.T) {
+		users := newUserFactory(chaos.New(t.Name()))
+		u := users.Build(func(u *factoryUser) { u.Email = "fixed@example.com" })
+		assert.Equal(t, "fixed@example.com", u.Email)
+	})
+
+	t.Run("applies traits", func(t *testing.T) {
+		users := newUserFactory(chaos.New(t.Name()))
+		admins := users.With("admin")
+		u := admins.With("inactive").Build()
+		assert.True(t, u.Admin)
+		assert.False(t, u.Active)
+		assert.True(t, admins.Build().Active, "derived factories do not alter their parent")
+		assert.False(t, users.Build().Admin)
+		assert.Panics(t, func() { users.With("superuser") })
+	})
+
+	t.Run("traits of derived factories stay local", func(t *testing.T) {
+		users := newUserFactory(chaos.New(t.Name()))
+		admins := users.With("admin").Trait("renamed", func(c *chaos.Chaos, u *factoryUser) {
+			u.Username = "root"
+		})
+		assert.Equal(t, "root", admins.With("renamed").Build().Username)
+		assert.Panics(t, func() { users.With("renamed") })
+		assert.Panics(t, func() { users.With("inactive").With("renamed") })
+	})
+
+	t.Run("builds associations", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		users := newUserFactory(c)
+		posts := chaos.NewFactory(c, func(c *chaos.Chaos, p *factoryPost) {
+			p.Title = c.Title(2, 5)
+			p.Author = users.With("admin").Generate(c)
+		})
+		built := posts.BuildN(2)
+		assert.Equal(t, "user-1", built[0].Author.Username)
+		assert.Equal(t, "user-2", built[1].Author.Username)
+		assert.True(t, built[0].Author.Admin)
+	})
+
+	t.Run("fills values without definition", func(t *testing.T) {
+		users := chaos.NewFactory[factoryUser](chaos.New(t.Name()), nil)
+		u := users.Build()
+		assert.NotEmpty(t, u.Username)
+		assert.NotEmpty(t, u.Email)
+	})
+
+	t.Run("is a generator", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		users := chaos.SliceOf[factoryUser](newUserFactory(c), 2, 2).Generate(c)
+		assert.Len(t, users, 2)
+	})
+}
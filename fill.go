@@ -0,0 +1,548 @@
+package chaos
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/raphoester/chaos/internal/tags"
+)
+
+var (
+	ErrInvalidFillTarget = errors.New("fill target must be a non-nil pointer")
+)
+
+// fillMaxDepth is the default maximum number of nested values of a recursive type.
+// The other defaults of Fill are shared with chaosgen, in internal/tags.
+const fillMaxDepth = 4
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	uuidType     = reflect.TypeOf(uuid.UUID{})
+)
+
+// derive returns an independent chaos whose values only depend on the seed of c, its count and name.
+// Using a derived chaos per name keeps values stable when unrelated values are added or removed.
+// The derived chaos shares the generators registered for c.
+func (c *Chaos) derive(name string) *Chaos {
+	derived := New(fmt.Sprintf("%s-%d/%s", c.seed, c.count, name))
+	derived.generators = c.generators
+	derived.size = c.size
+	derived.run = c.run
+	return derived
+}
+
+// Fill populates the value pointed to by ptr with random values.
+func Fill(ptr any) error {
+	return singleton.Fill(ptr)
+}
+
+// Fill walks the value pointed to by ptr and populates it with deterministic values.
+// Behavior:
+//   - Strings, numbers, booleans, time.Time, time.Duration and uuid.UUID are generated with the
+//     generators of Chaos. Integers span their whole range, floats are between -1e6 and 1e6,
+//     durations are at most 24 hours and strings have 10 alphanumerical characters.
+//   - Pointers are allocated, slices and maps get 1 to 5 items, arrays are fully populated,
+//     and nested structs are filled recursively. See FillWith to change these bounds.
+//   - Values of types with a registered generator, or implementing ChaosGenerator, are generated
+//     by it, see Register for the precedence rules.
+//   - Unexported fields, interfaces, channels and functions are left untouched.
+//   - Recursive types, like trees, are at most 4 levels deep: a pointer, slice or map leading
+//     back to a struct being filled is left nil more and more often as values nest.
+//   - Each value only depends on the seed and on its path in the struct, like "Address.Street"
+//     or "Tags[2]", so adding a field does not change the values of the others.
+//     Successive calls still produce different values unless the chaos is fixed.
+//   - If ptr is not a non-nil pointer, or if a struct tag is invalid, it returns an error.
+//
+// Fields are constrained with a chaos struct tag holding comma-separated options:
+//   - min=, max=: bounds of numbers, durations (like "90s") and times (in RFC 3339 format).
+//     When a single bound is set, the other one is the default bound.
+//   - len=, minlen=, maxlen=: length of strings, or number of items of slices and maps.
+//   - regex=: pattern matched by strings, see Regex. It must be the last option,
+//     as it consumes the rest of the tag.
+//   - oneof=: space-separated list of allowed values, like "oneof=red green blue".
+//   - charset=: characters strings are made of.
+//   - faker=: generator of strings, one of email, hostname, domain, url, ipv4, ipv6, mac,
+//     useragent, uuid, ulid, ksuid, objectid, word, sentence, paragraph, title, iban,
+//     bic, card, currency and token.
+//   - nil=: probability for a pointer, slice or map to be left nil, like "nil=0.3".
+//   - unique: values are never repeated across Fill calls of the same chaos, see UniqueScope.
+//   - keep: non-zero values are not overwritten.
+//   - skip, or "-": the field is left untouched.
+//
+// Length and nil options apply to the field itself, while the other options apply to the
+// innermost values: on a []int field, len is the number of items and min bounds each item.
+//
+// Fields with a validate tag, as used by github.com/go-playground/validator, get values passing
+// its rules: required, len, min, max, gt, gte, lt, lte, eq, ne, oneof, dive, and string formats
+// like email, url, hostname, ip, mac, uuid, alpha, alphanum or numeric. Rules without an
+// equivalent, like custom validations, are ignored. When a field has both tags,
+// the options of the chaos tag take precedence.
+func (c *Chaos) Fill(ptr any) error {
+	return c.fill(ptr, nil, FillOptions{})
+}
+
+// FillOptions bounds the size of the values populated by FillWith.
+// Zero fields keep the bounds of Fill.
+type FillOptions struct {
+	// MaxDepth is the maximum number of nested values of a recursive type, like the depth of a tree.
+	// A pointer, slice or map leading back to a struct being filled at depth d is left nil
+	// with probability d/MaxDepth. It defaults to 4, and 1 stops recursive types at the first cycle.
+	MaxDepth int
+	// MaxLen is the maximum number of items of slices and maps without length options.
+	// It defaults to 5.
+	MaxLen int
+	// MaxNodes is the maximum number of values populated, counting every pointer, item and field.
+	// Once it is reached, the remaining pointers, slices and maps are left nil and slices and maps
+	// being populated are truncated, even if that violates their length options.
+	// It defaults to no limit.
+	MaxNodes int
+}
+
+// FillWith populates the value pointed to by ptr with random values, within the bounds of opts.
+func FillWith(ptr any, opts FillOptions) error {
+	return singleton.FillWith(ptr, opts)
+}
+
+// FillWith populates the value pointed to by ptr like Fill, within the bounds of opts.
+// Values still only depend on their path, except when the MaxNodes budget is exhausted.
+func (c *Chaos) FillWith(ptr any, opts FillOptions) error {
+	return c.fill(ptr, nil, opts)
+}
+
+// FillViolating populates the value pointed to by ptr with random values, except for one field violating a rule.
+func FillViolating(ptr any, field, rule string) error {
+	return singleton.FillViolating(ptr, field, rule)
+}
+
+// FillViolating populates the value pointed to by ptr like Fill, except for the field at path
+// field, like "Address.Street" or "Items[0].Name", which violates rule of its validate tag.
+// The field keeps satisfying its other rules, so it is suited for negative tests of a single rule.
+// If the field cannot be found, or if it has no such rule or the rule cannot be violated
+// without violating another one, it returns an error.
+// The chaos tag of the violating field is ignored.
+func (c *Chaos) FillViolating(ptr any, field, rule string) error {
+	violation := &fillViolation{
+		path: field,
+		rule: rule,
+	}
+	if err := c.fill(ptr, violation, FillOptions{}); err != nil {
+		return err
+	}
+	if !violation.found {
+		return errors.Join(ErrUnknownField, fmt.Errorf("no field at %q in %T", field, ptr))
+	}
+	return nil
+}
+
+func (c *Chaos) fill(ptr any, violation *fillViolation, opts FillOptions) error {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return errors.Join(ErrInvalidFillTarget, fmt.Errorf("got %T", ptr))
+	}
+	if !c.fixed {
+		c.count++
+	}
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = fillMaxDepth
+	}
+	if opts.MaxLen <= 0 {
+		opts.MaxLen = tags.MaxLen
+	}
+	f := &filler{
+		c:          c,
+		root:       c.derive("fill"),
+		budget:     opts,
+		filling:    make(map[reflect.Type]int),
+		generators: make(map[reflect.Type]func(c *Chaos) reflect.Value),
+		violation:  violation,
+	}
+	return f.fill(v.Elem(), "", nil)
+}
+
+// fillViolation is the field violating a validation rule, see FillViolating.
+type fillViolation struct {
+	path  string
+	rule  string
+	found bool
+}
+
+// filler holds the state of a single Fill call.
+type filler struct {
+	// c is the chaos Fill was called on, holding the unique scopes.
+	c      *Chaos
+	root   *Chaos
+	budget FillOptions
+	// nodes is the number of values populated, bounded by the MaxNodes budget.
+	nodes int
+	// admitted is set by slices and maps admitted by the depth budget, so their items are not
+	// subject to it again.
+	admitted bool
+	// filling counts the structs of each type being filled, to detect recursive types.
+	filling map[reflect.Type]int
+	// generators caches the registered generator of each type, nil for types generated by default.
+	generators map[reflect.Type]func(c *Chaos) reflect.Value
+	violation  *fillViolation
+}
+
+// at returns the chaos generating the value at path.
+func (f *filler) at(path string) *Chaos {
+	return f.root.derive(path)
+}
+
+// fill populates v, the value at path, following opts.
+// opts is nil for values without a chaos tag.
+func (f *filler) fill(v reflect.Value, path string, opts *fieldOptions) error {
+	admitted := f.admitted
+	f.admitted = false
+	f.nodes++
+	if opts != nil && (opts.Skip || opts.Keep && !v.IsZero()) {
+		return nil
+	}
+	if opts != nil && opts.zero {
+		v.SetZero()
+		return nil
+	}
+	// The nil decision has its own path, so it is not correlated with the value.
+	if opts != nil && opts.NilRatio != nil && f.at(path+"?nil").Float64(1) < *opts.NilRatio {
+		v.SetZero()
+		return nil
+	}
+	if f.generator(v.Type(), opts) != nil {
+		return f.fillLeaf(v, path, opts)
+	}
+	c := f.at(path)
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !admitted && !f.grow(v.Type().Elem(), path) {
+			return nil
+		}
+		p := reflect.New(v.Type().Elem())
+		if err := f.fill(p.Elem(), path, opts.pointee()); err != nil {
+			return err
+		}
+		v.Set(p)
+	case reflect.Slice:
+		if !admitted && !f.grow(v.Type().Elem(), path) {
+			return nil
+		}
+		minLen, maxLen := opts.lengthRange(min(tags.MinLen, f.budget.MaxLen), f.budget.MaxLen)
+		s := reflect.MakeSlice(v.Type(), 0, 0)
+		var err error
+		c.repeat(minLen, maxLen, func() {
+			if err != nil || f.exhausted() {
+				return
+			}
+			item := reflect.New(v.Type().Elem()).Elem()
+			f.admitted = true
+			err = f.fill(item, path+"["+strconv.Itoa(s.Len())+"]", opts.element())
+			s = reflect.Append(s, item)
+		})
+		if err != nil {
+			return err
+		}
+		v.Set(s)
+	case reflect.Array:
+		if v.Type() == uuidType {
+			return f.fillLeaf(v, path, opts)
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := f.fill(v.Index(i), path+"["+strconv.Itoa(i)+"]", opts.element()); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if !admitted && (!f.grow(v.Type().Key(), path) || !f.grow(v.Type().Elem(), path)) {
+			return nil
+		}
+		minLen, maxLen := opts.lengthRange(min(tags.MinLen, f.budget.MaxLen), f.budget.MaxLen)
+		m := reflect.MakeMap(v.Type())
+		var err error
+		i := 0
+		c.repeat(minLen, maxLen, func() {
+			if err != nil || f.exhausted() {
+				return
+			}
+			f.admitted = true
+			key := reflect.New(v.Type().Key()).Elem()
+			if err = f.fill(key, path+"{key"+strconv.Itoa(i)+"}", nil); err != nil {
+				return
+			}
+			value := reflect.New(v.Type().Elem()).Elem()
+			f.admitted = true
+			if err = f.fill(value, path+"{value"+strconv.Itoa(i)+"}", opts.element()); err != nil {
+				return
+			}
+			m.SetMapIndex(key, value)
+			i++
+		})
+		if err != nil {
+			return err
+		}
+		v.Set(m)
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return f.fillLeaf(v, path, opts)
+		}
+		return f.fillStruct(v, path)
+	default:
+		return f.fillLeaf(v, path, opts)
+	}
+	return nil
+}
+
+func (f *filler) fillStruct(v reflect.Value, path string) error {
+	t := v.Type()
+	f.filling[t]++
+	defer func() { f.filling[t]-- }()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fieldPath := joinPath(path, field.Name)
+		opts, err := f.fieldOptions(t, field, fieldPath)
+		if err != nil {
+			return err
+		}
+		if err := f.fill(v.Field(i), fieldPath, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldOptions returns the options of a field of the struct type t, read from its validate and chaos tags.
+// It returns nil for fields without tags.
+func (f *filler) fieldOptions(t reflect.Type, field reflect.StructField, path string) (*fieldOptions, error) {
+	name := structName(t) + "." + field.Name
+	violate := ""
+	if f.violation != nil && f.violation.path == path {
+		violate = f.violation.rule
+		f.violation.found = true
+	}
+
+	var opts *fieldOptions
+	if tag, ok := field.Tag.Lookup(validateTagName); ok || violate != "" {
+		var err error
+		if opts, err = validateOptions(name, field.Type, tag, violate); err != nil {
+			return nil, err
+		}
+	}
+	if tag, ok := field.Tag.Lookup(tags.Name); ok && violate == "" {
+		tagged, err := parseFieldTag(name, tag)
+		switch {
+		case err != nil:
+			return nil, err
+		case opts == nil:
+			opts = tagged
+		default:
+			opts = opts.override(tagged)
+		}
+	}
+	if opts == nil {
+		return nil, nil
+	}
+	if err := opts.compile(field.Type); err != nil {
+		if violate != "" {
+			return nil, errors.Join(ErrUnviolatableValidation, err)
+		}
+		return nil, err
+	}
+	return opts, nil
+}
+
+// fillLeaf populates v, a value that is not a container.
+// Values rejected by the options, or already issued for unique fields, are generated
+// again at derived paths until a valid one is found.
+func (f *filler) fillLeaf(v reflect.Value, path string, opts *fieldOptions) error {
+	if opts == nil || !opts.Unique && !opts.nonZero && len(opts.excludeValues) == 0 && !opts.boundsLength(v.Type()) {
+		f.generate(v, f.at(path), opts)
+		return nil
+	}
+	kind := v.Type().String()
+	var u *UniqueGenerator
+	if opts.Unique {
+		u = f.c.UniqueScope(opts.name)
+	}
+	for attempt := 0; attempt < maxUniqueRetries; attempt++ {
+		attemptPath := path
+		if attempt > 0 {
+			attemptPath += "#" + strconv.Itoa(attempt)
+		}
+		candidate := reflect.New(v.Type()).Elem()
+		f.generate(candidate, f.at(attemptPath), opts)
+		if !opts.accepts(candidate) {
+			continue
+		}
+		if u != nil {
+			set := u.set(kind)
+			if _, ok := set[candidate.Interface()]; ok {
+				continue
+			}
+			set[candidate.Interface()] = struct{}{}
+		}
+		v.Set(candidate)
+		return nil
+	}
+	reason := fmt.Sprintf("no valid value after %d attempts", maxUniqueRetries)
+	if u != nil {
+		return errors.Join(u.exhausted(kind, reason), fmt.Errorf("field %s", opts.name))
+	}
+	return opts.errorf("%s", reason)
+}
+
+// generate sets v to a value generated by c, following opts.
+func (f *filler) generate(v reflect.Value, c *Chaos, opts *fieldOptions) {
+	if gen := f.generator(v.Type(), opts); gen != nil {
+		v.Set(gen(c))
+		return
+	}
+	if opts != nil && len(opts.oneofValues) > 0 {
+		v.Set(NewSliceProcessor[[]reflect.Value](c).Item(opts.oneofValues))
+		return
+	}
+	hasBounds := opts != nil && (opts.Min != nil || opts.Max != nil)
+
+	switch v.Type() {
+	case timeType:
+		if hasBounds {
+			v.Set(reflect.ValueOf(c.TimeBetween(opts.timeMin, opts.timeMax)))
+		} else {
+			v.Set(reflect.ValueOf(c.Time()))
+		}
+		return
+	case durationType:
+		if hasBounds {
+			v.SetInt(c.int64Between(opts.intMin, opts.intMax))
+		} else {
+			v.SetInt(int64(c.Duration(tags.MaxDuration)))
+		}
+		return
+	case uuidType:
+		v.Set(reflect.ValueOf(c.UUID()))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(f.generateString(c, opts))
+	case reflect.Bool:
+		v.SetBool(c.Int(1) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		lo, hi := intBounds(v.Type())
+		if hasBounds {
+			lo, hi = opts.intMin, opts.intMax
+		}
+		v.SetInt(c.int64Between(lo, hi))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		lo, hi := uint64(0), uintMax(v.Type())
+		if hasBounds {
+			lo, hi = opts.uintMin, opts.uintMax
+		}
+		v.SetUint(c.Uint64Between(lo, hi))
+	case reflect.Float32, reflect.Float64:
+		lo, hi := -tags.MaxFloat, tags.MaxFloat
+		if hasBounds {
+			lo, hi = opts.floatMin, opts.floatMax
+		}
+		v.SetFloat(c.Float64Between(lo, hi))
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(complex(
+			c.Float64Between(-tags.MaxFloat, tags.MaxFloat),
+			c.Float64Between(-tags.MaxFloat, tags.MaxFloat)))
+	}
+}
+
+func (f *filler) generateString(c *Chaos, opts *fieldOptions) string {
+	if opts == nil {
+		return c.String(tags.StringLength)
+	}
+	switch {
+	case opts.Regex != nil:
+		return must(c.Regex(*opts.Regex))
+	case opts.Faker != nil:
+		return fillFakers[*opts.Faker](c)
+	}
+	lo, hi := opts.lengthRange(tags.StringLength, tags.StringLength)
+	length := c.IntBetween(lo, hi)
+	if opts.Charset != nil {
+		return c.StringFrom(*opts.Charset, length)
+	}
+	return c.String(length)
+}
+
+// int64Between returns a uniform int64 between min and max (inclusive), even when the range overflows int64.
+func (c *Chaos) int64Between(min, max int64) int64 {
+	if c.run != nil && min < 0 && max > 0 {
+		return c.chooseNearZero(min, max)
+	}
+	const signBit = 1 << 63
+	return int64(c.Uint64Between(uint64(min)^signBit, uint64(max)^signBit) ^ signBit)
+}
+
+// generator returns the registered generator of values of type t, see Register.
+// It returns nil if the values are generated by default, or by the value options of opts.
+func (f *filler) generator(t reflect.Type, opts *fieldOptions) func(c *Chaos) reflect.Value {
+	if opts.constrainsValue(t) {
+		return nil
+	}
+	gen, ok := f.generators[t]
+	if !ok {
+		gen = f.c.generator(t)
+		f.generators[t] = gen
+	}
+	return gen
+}
+
+// grow reports whether a pointer, slice or map at path holding values of type t is populated,
+// within the budget. Values leading back to a struct being filled are populated less and less
+// often as they nest, and never beyond MaxDepth.
+func (f *filler) grow(t reflect.Type, path string) bool {
+	if f.exhausted() {
+		return false
+	}
+	depth := f.depth(t)
+	if depth == 0 {
+		return true
+	}
+	if depth >= f.budget.MaxDepth {
+		return false
+	}
+	return f.at(path+"?depth").Float64(1) >= float64(depth)/float64(f.budget.MaxDepth)
+}
+
+// exhausted reports whether the MaxNodes budget is reached.
+func (f *filler) exhausted() bool {
+	return f.budget.MaxNodes > 0 && f.nodes >= f.budget.MaxNodes
+}
+
+// depth returns the number of structs being filled that values of type t may contain,
+// or 0 if t does not lead back to a struct being filled.
+func (f *filler) depth(t reflect.Type) int {
+	for {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array:
+			t = t.Elem()
+		case reflect.Map:
+			return max(f.depth(t.Key()), f.depth(t.Elem()))
+		case reflect.Struct:
+			return f.filling[t]
+		default:
+			return 0
+		}
+	}
+}
+
+// joinPath appends the field name to the path of its struct.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
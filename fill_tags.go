@@ -0,0 +1,352 @@
+package chaos
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/raphoester/chaos/internal/tags"
+)
+
+var (
+	ErrInvalidTag = errors.New("invalid struct tag")
+)
+
+// fieldOptions are the options of a field, parsed from its chaos tag.
+//
+// Options controlling nil values and lengths apply to the outermost pointer, slice, map
+// or string of the field, while options constraining values apply to the innermost values:
+// on a []int field, len sets the number of items and min and max bound each item.
+type fieldOptions struct {
+	// name identifies the field in errors and unique scopes, like "User.Email".
+	name string
+
+	tags.Options
+	// nonZero rejects zero values, and zero forces them, see the validate tag.
+	nonZero, zero bool
+
+	intMin, intMax     int64
+	uintMin, uintMax   uint64
+	floatMin, floatMax float64
+	timeMin, timeMax   time.Time
+	oneofValues        []reflect.Value
+	exclude            []string
+	excludeValues      []reflect.Value
+	// dive holds the options of the items of a slice, array or map, when they differ
+	// from the options of the field itself.
+	dive *fieldOptions
+}
+
+// parseFieldTag parses the chaos tag of a field.
+func parseFieldTag(name, tag string) (*fieldOptions, error) {
+	opts := &fieldOptions{name: name}
+	parsed, err := tags.Parse(tag, func(faker string) bool {
+		_, ok := fillFakers[faker]
+		return ok
+	})
+	if err != nil {
+		return nil, opts.errorf("%v", err)
+	}
+	opts.Options = *parsed
+	return opts, nil
+}
+
+// override returns the options of o, replaced by the options set in over.
+// It merges the options read from the validate tag with the ones of the chaos tag,
+// which take precedence.
+func (o *fieldOptions) override(over *fieldOptions) *fieldOptions {
+	merged := *o
+	merged.Skip = o.Skip || over.Skip
+	merged.Keep = o.Keep || over.Keep
+	merged.Unique = o.Unique || over.Unique
+	merged.nonZero = o.nonZero || over.nonZero
+	merged.zero = o.zero || over.zero
+	merged.exclude = append(slices.Clip(o.exclude), over.exclude...)
+	if over.dive != nil {
+		merged.dive = over.dive
+	}
+	if over.NilRatio != nil {
+		merged.NilRatio = over.NilRatio
+	}
+	if over.Length != nil || over.MinLen != nil || over.MaxLen != nil {
+		merged.Length, merged.MinLen, merged.MaxLen = over.Length, over.MinLen, over.MaxLen
+	}
+	if over.Min != nil || over.Max != nil || over.Oneof != nil {
+		merged.Min, merged.Max, merged.Oneof = over.Min, over.Max, over.Oneof
+	}
+	if over.Regex != nil || over.Oneof != nil || over.Charset != nil || over.Faker != nil {
+		merged.Regex, merged.Oneof, merged.Charset, merged.Faker = over.Regex, over.Oneof, over.Charset, over.Faker
+	}
+	return &merged
+}
+
+func (o *fieldOptions) errorf(format string, args ...any) error {
+	return errors.Join(ErrInvalidTag, fmt.Errorf("field %s: %s", o.name, fmt.Sprintf(format, args...)))
+}
+
+// compile checks that the options apply to a field of type t, and parses
+// the values of min, max and oneof according to the type of the innermost values.
+func (o *fieldOptions) compile(t reflect.Type) error {
+	if o.MinLen != nil && o.MaxLen != nil && *o.MinLen > *o.MaxLen {
+		return o.errorf("minlen %d is greater than maxlen %d", *o.MinLen, *o.MaxLen)
+	}
+	if o.NilRatio != nil && !nillable(t.Kind()) {
+		return o.errorf("nil does not apply to %s", t)
+	}
+	outer := t
+	for outer.Kind() == reflect.Pointer {
+		outer = outer.Elem()
+	}
+	if o.HasLength() && outer.Kind() != reflect.String && outer.Kind() != reflect.Slice && outer.Kind() != reflect.Map {
+		return o.errorf("len, minlen and maxlen do not apply to %s", t)
+	}
+
+	leaf := leafType(t)
+	if o.Unique && (leaf.Kind() == reflect.Struct && leaf != timeType || !leaf.Comparable()) {
+		return o.errorf("unique does not apply to %s", leaf)
+	}
+	if (o.Regex != nil || o.Charset != nil || o.Faker != nil) && leaf.Kind() != reflect.String {
+		return o.errorf("regex, charset and faker do not apply to %s", leaf)
+	}
+
+	if o.Min != nil || o.Max != nil {
+		if err := o.compileBounds(leaf); err != nil {
+			return err
+		}
+	}
+	for _, raw := range o.Oneof {
+		value, err := parseValue(leaf, raw)
+		if err != nil {
+			return o.errorf("oneof: %v", err)
+		}
+		o.oneofValues = append(o.oneofValues, value)
+	}
+	for _, raw := range o.exclude {
+		value, err := parseValue(leaf, raw)
+		if err != nil {
+			return o.errorf("%v", err)
+		}
+		o.excludeValues = append(o.excludeValues, value)
+	}
+
+	if o.dive != nil {
+		switch outer.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return o.dive.compile(outer.Elem())
+		default:
+			return o.errorf("dive does not apply to %s", t)
+		}
+	}
+	return nil
+}
+
+// compileBounds parses min and max for values of type t, defaulting to the bounds used by Fill.
+func (o *fieldOptions) compileBounds(t reflect.Type) error {
+	var err error
+	switch {
+	case t == timeType:
+		o.timeMin, o.timeMax, err = o.TimeBounds()
+	case t == durationType:
+		var lo, hi time.Duration
+		lo, hi, err = o.DurationBounds()
+		o.intMin, o.intMax = int64(lo), int64(hi)
+	case isInt(t.Kind()):
+		o.intMin, o.intMax, err = o.IntBounds(t.Bits())
+	case isUint(t.Kind()):
+		o.uintMin, o.uintMax, err = o.UintBounds(t.Bits())
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		o.floatMin, o.floatMax, err = o.FloatBounds(t.Bits())
+	default:
+		return o.errorf("min and max do not apply to %s", t)
+	}
+	if err != nil {
+		return o.errorf("%v", err)
+	}
+	return nil
+}
+
+// parseValue parses raw as a value of type t.
+// Durations use the syntax of time.ParseDuration, and times the RFC 3339 format.
+func parseValue(t reflect.Type, raw string) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	switch {
+	case t == timeType:
+		parsed, err := tags.ParseTime(raw)
+		if err != nil {
+			return v, err
+		}
+		v.Set(reflect.ValueOf(parsed))
+	case t == durationType:
+		parsed, err := tags.ParseDuration(raw)
+		if err != nil {
+			return v, err
+		}
+		v.SetInt(int64(parsed))
+	case t.Kind() == reflect.String:
+		v.SetString(raw)
+	case t.Kind() == reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return v, err
+		}
+		v.SetBool(parsed)
+	case isInt(t.Kind()):
+		parsed, err := tags.ParseInt(raw, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetInt(parsed)
+	case isUint(t.Kind()):
+		parsed, err := tags.ParseUint(raw, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetUint(parsed)
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		parsed, err := tags.ParseFloat(raw, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetFloat(parsed)
+	default:
+		return v, fmt.Errorf("cannot parse values of %s", t)
+	}
+	return v, nil
+}
+
+// accepts reports whether v satisfies the options that cannot be enforced while generating values.
+func (o *fieldOptions) accepts(v reflect.Value) bool {
+	if o.nonZero && v.IsZero() {
+		return false
+	}
+	for _, excluded := range o.excludeValues {
+		if v.Equal(excluded) {
+			return false
+		}
+	}
+	if o.boundsLength(v.Type()) {
+		n := utf8.RuneCountInString(v.String())
+		lo, hi := o.lengthRange(0, math.MaxInt)
+		return n >= lo && n <= hi
+	}
+	return true
+}
+
+// boundsLength reports whether o sets the length of values of type t, which generators such as
+// fakers and regular expressions do not follow, so the values are checked by accepts.
+func (o *fieldOptions) boundsLength(t reflect.Type) bool {
+	return t.Kind() == reflect.String && o.HasLength()
+}
+
+// constrainsValue reports whether o sets the values of type t, rather than only the
+// container or the flags of the field.
+func (o *fieldOptions) constrainsValue(t reflect.Type) bool {
+	return o != nil && (o.SetsValue() || o.boundsLength(t))
+}
+
+// pointee returns the options of the value held by a pointer.
+func (o *fieldOptions) pointee() *fieldOptions {
+	if o == nil {
+		return nil
+	}
+	elem := *o
+	elem.Options = *o.Pointee()
+	return &elem
+}
+
+// element returns the options of the items of a slice, array or map,
+// without the options that only apply to the container.
+func (o *fieldOptions) element() *fieldOptions {
+	if o == nil {
+		return nil
+	}
+	if o.dive != nil {
+		return o.dive
+	}
+	elem := *o
+	elem.Options = *o.Element()
+	return &elem
+}
+
+// lengthRange returns the length bounds set by the options, defaulting to [lo, hi].
+func (o *fieldOptions) lengthRange(lo, hi int) (int, int) {
+	if o == nil {
+		return lo, hi
+	}
+	return o.LengthRange(lo, hi)
+}
+
+// leafType returns the type of the innermost values of t, through pointers, slices, arrays and maps.
+func leafType(t reflect.Type) reflect.Type {
+	for {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			if t == uuidType {
+				return t
+			}
+			t = t.Elem()
+		default:
+			return t
+		}
+	}
+}
+
+func nillable(k reflect.Kind) bool {
+	return k == reflect.Pointer || k == reflect.Slice || k == reflect.Map
+}
+
+func isInt(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUint(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+// intBounds returns the smallest and largest values of the signed integer type t.
+func intBounds(t reflect.Type) (int64, int64) {
+	return tags.IntRange(t.Bits())
+}
+
+// uintMax returns the largest value of the unsigned integer type t.
+func uintMax(t reflect.Type) uint64 {
+	return tags.UintMax(t.Bits())
+}
+
+// structName returns the name of a struct type, for error messages and unique scopes.
+func structName(t reflect.Type) string {
+	if t.Name() != "" {
+		return t.Name()
+	}
+	return t.String()
+}
+
+// fillFakers are the generators available to the faker option.
+var fillFakers = map[string]func(c *Chaos) string{
+	"email":     (*Chaos).Email,
+	"hostname":  (*Chaos).Hostname,
+	"domain":    func(c *Chaos) string { return c.Domain("") },
+	"url":       func(c *Chaos) string { return c.URL(URLOptions{PathSegments: 2}) },
+	"ipv4":      func(c *Chaos) string { return c.IPv4().String() },
+	"ipv6":      func(c *Chaos) string { return c.IPv6().String() },
+	"mac":       func(c *Chaos) string { return c.MAC().String() },
+	"useragent": (*Chaos).UserAgent,
+	"uuid":      func(c *Chaos) string { return c.UUID().String() },
+	"ulid":      (*Chaos).ULID,
+	"ksuid":     (*Chaos).KSUID,
+	"objectid":  (*Chaos).ObjectID,
+	"word":      (*Chaos).Word,
+	"sentence":  func(c *Chaos) string { return c.Sentence(4, 12) },
+	"paragraph": func(c *Chaos) string { return c.Paragraph(3) },
+	"title":     func(c *Chaos) string { return c.Title(2, 6) },
+	"iban":      func(c *Chaos) string { return must(c.IBAN("")) },
+	"bic":       (*Chaos).BIC,
+	"card":      func(c *Chaos) string { return c.CardNumber(NewSliceProcessor[[]CardNetwork](c).Item(CardNetworks())) },
+	"currency":  func(c *Chaos) string { return c.Currency().Code },
+	"token":     func(c *Chaos) string { return c.HexToken(16) },
+}
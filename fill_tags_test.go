@@ -0,0 +1,183 @@
+package chaos_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raphoester/chaos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type taggedAccount struct {
+	Age       int           `chaos:"min=18,max=99"`
+	Balance   float64       `chaos:"min=0,max=100"`
+	Timeout   time.Duration `chaos:"min=1s,max=1m"`
+	CreatedAt time.Time     `chaos:"min=2020-01-01T00:00:00Z,max=2021-01-01T00:00:00Z"`
+	Code      string        `chaos:"len=6,charset=ABC"`
+	Nickname  string        `chaos:"minlen=3,maxlen=5"`
+	Reference string        `chaos:"regex=^[A-Z]{2}-\\d{3,5}$"`
+	Status    string        `chaos:"oneof=active suspended closed"`
+	Level     uint8         `chaos:"oneof=1 2 3"`
+	Email     string        `chaos:"faker=email"`
+	Tags      []string      `chaos:"len=3,faker=word"`
+	Scores    []int         `chaos:"minlen=2,maxlen=4,min=0,max=10"`
+	Manager   *string       `chaos:"nil=1"`
+	Internal  string        `chaos:"-"`
+	Country   string        `chaos:"keep,len=2"`
+}
+
+func TestFillTags(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		var a1, a2 taggedAccount
+		require.NoError(t, chaos.New(t.Name()).Fill(&a1))
+		require.NoError(t, chaos.New(t.Name()).Fill(&a2))
+		assert.Equal(t, a1, a2)
+	})
+
+	t.Run("respects constraints", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 100; i++ {
+			a := taggedAccount{Internal: "untouched", Country: "FR"}
+			require.NoError(t, c.Fill(&a))
+			assert.GreaterOrEqual(t, a.Age, 18)
+			assert.LessOrEqual(t, a.Age, 99)
+			assert.GreaterOrEqual(t, a.Balance, 0.0)
+			assert.LessOrEqual(t, a.Balance, 100.0)
+			assert.GreaterOrEqual(t, a.Timeout, time.Second)
+			assert.LessOrEqual(t, a.Timeout, time.Minute)
+			assert.Equal(t, 2020, a.CreatedAt.UTC().Year())
+			assert.Regexp(t, `^[ABC]{6}$`, a.Code)
+			assert.GreaterOrEqual(t, len(a.Nickname), 3)
+			assert.LessOrEqual(t, len(a.Nickname), 5)
+			assert.Regexp(t, `^[A-Z]{2}-\d{3,5}$`, a.Reference)
+			assert.Contains(t, []string{"active", "suspended", "closed"}, a.Status)
+			assert.Contains(t, []uint8{1, 2, 3}, a.Level)
+			assert.Contains(t, a.Email, "@")
+			assert.Len(t, a.Tags, 3)
+			assert.GreaterOrEqual(t, len(a.Scores), 2)
+			assert.LessOrEqual(t, len(a.Scores), 4)
+			for _, score := range a.Scores {
+				assert.GreaterOrEqual(t, score, 0)
+				assert.LessOrEqual(t, score, 10)
+			}
+			assert.Nil(t, a.Manager)
+			assert.Equal(t, "untouched", a.Internal)
+			assert.Equal(t, "FR", a.Country)
+		}
+	})
+
+	t.Run("keep fills zero values", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		var a taggedAccount
+		require.NoError(t, c.Fill(&a))
+		assert.Len(t, a.Country, 2)
+	})
+
+	t.Run("nil ratio", func(t *testing.T) {
+		type optional struct {
+			Value *int `chaos:"nil=0.3,min=0,max=9"`
+		}
+		c := chaos.New(t.Name())
+		nils := 0
+		for i := 0; i < 1000; i++ {
+			var o optional
+			require.NoError(t, c.Fill(&o))
+			if o.Value == nil {
+				nils++
+			} else {
+				assert.LessOrEqual(t, *o.Value, 9)
+			}
+		}
+		assert.InDelta(t, 300, nils, 60)
+	})
+
+	t.Run("unique values across calls", func(t *testing.T) {
+		type user struct {
+			ID int `chaos:"unique,min=1,max=50"`
+		}
+		c := chaos.New(t.Name())
+		seen := make(map[int]bool)
+		for i := 0; i < 50; i++ {
+			var u user
+			require.NoError(t, c.Fill(&u))
+			require.False(t, seen[u.ID], "id %d issued twice", u.ID)
+			seen[u.ID] = true
+		}
+		var u user
+		assert.ErrorIs(t, c.Fill(&u), chaos.ErrUniqueExhausted)
+	})
+
+	t.Run("single bounds keep the default range", func(t *testing.T) {
+		type bounded struct {
+			Timeout time.Duration `chaos:"min=48h"`
+			Ratio   float64       `chaos:"max=-2e6"`
+		}
+		c := chaos.New(t.Name())
+		var b bounded
+		require.NoError(t, c.Fill(&b))
+		assert.GreaterOrEqual(t, b.Timeout, 48*time.Hour)
+		assert.LessOrEqual(t, b.Ratio, -2e6)
+	})
+}
+
+func TestFillTagsErrors(t *testing.T) {
+	cases := map[string]any{
+		"unknown option": &struct {
+			A string `chaos:"color=red"`
+		}{},
+		"missing value": &struct {
+			A int `chaos:"min="`
+		}{},
+		"duplicate option": &struct {
+			A int `chaos:"min=1,min=2"`
+		}{},
+		"min greater than max": &struct {
+			A int `chaos:"min=5,max=1"`
+		}{},
+		"min out of range": &struct {
+			A int8 `chaos:"min=1000"`
+		}{},
+		"min on string": &struct {
+			A string `chaos:"min=1"`
+		}{},
+		"regex on int": &struct {
+			A int `chaos:"regex=\\d+"`
+		}{},
+		"invalid regex": &struct {
+			A string `chaos:"regex=[a-"`
+		}{},
+		"unknown faker": &struct {
+			A string `chaos:"faker=unicorn"`
+		}{},
+		"nil on value": &struct {
+			A string `chaos:"nil=0.5"`
+		}{},
+		"nil out of range": &struct {
+			A *string `chaos:"nil=2"`
+		}{},
+		"len on int": &struct {
+			A int `chaos:"len=2"`
+		}{},
+		"conflicting sources": &struct {
+			A string `chaos:"faker=email,charset=abc"`
+		}{},
+		"invalid oneof value": &struct {
+			A int `chaos:"oneof=1 two"`
+		}{},
+		"unique on structs": &struct {
+			A []struct{ B int } `chaos:"unique"`
+		}{},
+		"flag with value": &struct {
+			A string `chaos:"skip=true"`
+		}{},
+	}
+	for name, target := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := chaos.New(t.Name()).Fill(target)
+			require.ErrorIs(t, err, chaos.ErrInvalidTag)
+			assert.True(t, strings.Contains(err.Error(), ".A:"), "error should name the field: %v", err)
+		})
+	}
+}
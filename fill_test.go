@@ -0,0 +1,221 @@
+package chaos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/raphoester/chaos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fillAddress struct {
+	Street string
+	Zip    uint16
+}
+
+type fillUser struct {
+	ID        uuid.UUID
+	Name      string
+	Age       int8
+	Score     float64
+	Admin     bool
+	CreatedAt time.Time
+	Timeout   time.Duration
+	Address   fillAddress
+	Previous  *fillAddress
+	Tags      []string
+	Scores    [3]int
+	Attrs     map[string]int
+	secret    string
+}
+
+type fillNode struct {
+	Value    int
+	Next     *fillNode
+	Children []fillNode
+}
+
+func TestFill(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		var u1, u2 fillUser
+		require.NoError(t, chaos.New(t.Name()).Fill(&u1))
+		require.NoError(t, chaos.New(t.Name()).Fill(&u2))
+		assert.Equal(t, u1, u2)
+	})
+
+	t.Run("populates every supported kind", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		var u fillUser
+		require.NoError(t, c.Fill(&u))
+		assert.NotEqual(t, uuid.Nil, u.ID)
+		assert.Len(t, u.Name, 10)
+		assert.False(t, u.CreatedAt.IsZero())
+		assert.LessOrEqual(t, u.Timeout, 24*time.Hour)
+		assert.NotEmpty(t, u.Address.Street)
+		require.NotNil(t, u.Previous)
+		assert.NotEmpty(t, u.Previous.Street)
+		assert.NotEmpty(t, u.Tags)
+		assert.LessOrEqual(t, len(u.Tags), 5)
+		assert.NotEmpty(t, u.Attrs)
+		assert.NotEqual(t, [3]int{}, u.Scores)
+		assert.Empty(t, u.secret)
+	})
+
+	t.Run("values only depend on their path", func(t *testing.T) {
+		type before struct {
+			Name string
+		}
+		type after struct {
+			Email string
+			Name  string
+		}
+		var b before
+		var a after
+		require.NoError(t, chaos.New(t.Name()).Fill(&b))
+		require.NoError(t, chaos.New(t.Name()).Fill(&a))
+		assert.Equal(t, b.Name, a.Name)
+		assert.NotEqual(t, a.Email, a.Name)
+	})
+
+	t.Run("successive calls produce different values", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		var u1, u2 fillUser
+		require.NoError(t, c.Fill(&u1))
+		require.NoError(t, c.Fill(&u2))
+		assert.NotEqual(t, u1, u2)
+
+		c.Fix()
+		require.NoError(t, c.Fill(&u1))
+		require.NoError(t, c.Fill(&u2))
+		assert.Equal(t, u1, u2)
+	})
+
+	t.Run("bounds recursive types", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		nested := false
+		for i := 0; i < 100; i++ {
+			var n fillNode
+			require.NoError(t, c.Fill(&n))
+			assert.NotZero(t, n.Value)
+			assert.LessOrEqual(t, nodeDepth(n), 4)
+			nested = nested || nodeDepth(n) > 1
+		}
+		assert.True(t, nested)
+	})
+
+	t.Run("fills non-struct values", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		var ids []uuid.UUID
+		require.NoError(t, c.Fill(&ids))
+		assert.NotEmpty(t, ids)
+	})
+
+	t.Run("returns error for invalid targets", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		var u fillUser
+		assert.ErrorIs(t, c.Fill(u), chaos.ErrInvalidFillTarget)
+		assert.ErrorIs(t, c.Fill((*fillUser)(nil)), chaos.ErrInvalidFillTarget)
+	})
+}
+
+func TestFillWith(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		opts := chaos.FillOptions{MaxDepth: 6, MaxLen: 3, MaxNodes: 200}
+		var n1, n2 fillNode
+		require.NoError(t, chaos.New(t.Name()).FillWith(&n1, opts))
+		require.NoError(t, chaos.New(t.Name()).FillWith(&n2, opts))
+		assert.Equal(t, n1, n2)
+	})
+
+	t.Run("max depth", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		deepest := 0
+		for i := 0; i < 100; i++ {
+			var n fillNode
+			require.NoError(t, c.FillWith(&n, chaos.FillOptions{MaxDepth: 8}))
+			assert.LessOrEqual(t, nodeDepth(n), 8)
+			deepest = max(deepest, nodeDepth(n))
+		}
+		assert.Greater(t, deepest, 4)
+	})
+
+	t.Run("max depth of 1 stops at the first cycle", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		var n fillNode
+		require.NoError(t, c.FillWith(&n, chaos.FillOptions{MaxDepth: 1}))
+		assert.Nil(t, n.Next)
+		assert.Nil(t, n.Children)
+	})
+
+	t.Run("max len", func(t *testing.T) {
+		type lists struct {
+			Default []int
+			Tagged  []int `chaos:"len=8"`
+			Matrix  [][]string
+		}
+		c := chaos.New(t.Name())
+		for i := 0; i < 100; i++ {
+			var l lists
+			require.NoError(t, c.FillWith(&l, chaos.FillOptions{MaxLen: 2}))
+			assert.LessOrEqual(t, len(l.Default), 2)
+			assert.Len(t, l.Tagged, 8)
+			assert.LessOrEqual(t, len(l.Matrix), 2)
+			for _, row := range l.Matrix {
+				assert.LessOrEqual(t, len(row), 2)
+			}
+		}
+	})
+
+	t.Run("max nodes", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 100; i++ {
+			var n fillNode
+			require.NoError(t, c.FillWith(&n, chaos.FillOptions{MaxDepth: 10, MaxNodes: 50}))
+			// Each node holds 3 values: the fields of the last node may exceed the budget.
+			assert.LessOrEqual(t, nodeCount(n), 50/3+1)
+		}
+	})
+
+	t.Run("items of recursive slices are populated", func(t *testing.T) {
+		type tree struct {
+			Children []*tree
+		}
+		c := chaos.New(t.Name())
+		var check func(n *tree)
+		check = func(n *tree) {
+			for _, child := range n.Children {
+				require.NotNil(t, child)
+				check(child)
+			}
+		}
+		for i := 0; i < 100; i++ {
+			var n tree
+			require.NoError(t, c.Fill(&n))
+			check(&n)
+		}
+	})
+}
+
+func nodeDepth(n fillNode) int {
+	depth := 0
+	if n.Next != nil {
+		depth = nodeDepth(*n.Next)
+	}
+	for _, child := range n.Children {
+		depth = max(depth, nodeDepth(child))
+	}
+	return depth + 1
+}
+
+func nodeCount(n fillNode) int {
+	count := 1
+	if n.Next != nil {
+		count += nodeCount(*n.Next)
+	}
+	for _, child := range n.Children {
+		count += nodeCount(child)
+	}
+	return count
+}
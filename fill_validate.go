@@ -0,0 +1,384 @@
+package chaos
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/raphoester/chaos/internal/tags"
+)
+
+var (
+	ErrUnknownField           = errors.New("unknown field")
+	ErrUnviolatableValidation = errors.New("validation rule cannot be violated")
+)
+
+// validateTagName is the name of the struct tag of github.com/go-playground/validator.
+const validateTagName = "validate"
+
+// invalidFormatChars are the characters of strings violating format rules, like email or uuid.
+const invalidFormatChars = "!#$%&*"
+
+// validateRule is a rule of a validate tag, like "min=3".
+type validateRule struct {
+	name, param string
+}
+
+// validateFormat describes how to satisfy and how to violate a validator rule on strings.
+type validateFormat struct {
+	apply func(o *fieldOptions)
+	// violation is the charset of strings violating the rule.
+	violation string
+}
+
+func fakerFormat(faker string) validateFormat {
+	return validateFormat{
+		apply:     func(o *fieldOptions) { o.Faker = &faker },
+		violation: invalidFormatChars,
+	}
+}
+
+func charsetFormat(charset, violation string) validateFormat {
+	return validateFormat{
+		apply:     func(o *fieldOptions) { o.Charset = &charset },
+		violation: violation,
+	}
+}
+
+// validateFormats are the validator rules on strings supported by Fill.
+var validateFormats = map[string]validateFormat{
+	"email":            fakerFormat("email"),
+	"url":              fakerFormat("url"),
+	"uri":              fakerFormat("url"),
+	"http_url":         fakerFormat("url"),
+	"hostname":         fakerFormat("hostname"),
+	"hostname_rfc1123": fakerFormat("hostname"),
+	"fqdn":             fakerFormat("hostname"),
+	"ip":               fakerFormat("ipv4"),
+	"ipv4":             fakerFormat("ipv4"),
+	"ip4_addr":         fakerFormat("ipv4"),
+	"ipv6":             fakerFormat("ipv6"),
+	"ip6_addr":         fakerFormat("ipv6"),
+	"mac":              fakerFormat("mac"),
+	"uuid":             fakerFormat("uuid"),
+	"uuid4":            fakerFormat("uuid"),
+	"uuid_rfc4122":     fakerFormat("uuid"),
+	"uuid4_rfc4122":    fakerFormat("uuid"),
+	"ulid":             fakerFormat("ulid"),
+	"mongodb":          fakerFormat("objectid"),
+	"iso4217":          fakerFormat("currency"),
+	"credit_card":      fakerFormat("card"),
+	"alpha":            charsetFormat(lowerChars+upperChars, digitChars),
+	"alphanum":         charsetFormat(alphanumericalChars, invalidFormatChars),
+	"numeric":          charsetFormat(digitChars, lowerChars),
+	"number":           charsetFormat(digitChars, lowerChars),
+	"hexadecimal":      charsetFormat("0123456789abcdef", "ghijklmnopqrstuvwxyz"),
+	"lowercase":        charsetFormat(lowerChars, upperChars),
+	"uppercase":        charsetFormat(upperChars, lowerChars),
+	"ascii":            charsetFormat(alphanumericalChars, "éèàùç"),
+	"printascii":       charsetFormat(alphanumericalChars, "éèàùç"),
+	"e164": {
+		apply: func(o *fieldOptions) {
+			pattern := `\+[1-9]\d{7,14}`
+			o.Regex = &pattern
+		},
+		violation: invalidFormatChars,
+	},
+	"boolean": {
+		apply:     func(o *fieldOptions) { o.Oneof = []string{"true", "false"} },
+		violation: lowerChars,
+	},
+}
+
+// parseValidateTag splits a validate tag into the rules of the field, followed by
+// the rules of its items after each dive. Rules on map keys are ignored, and only
+// the first alternative of "a|b" rules is kept.
+func parseValidateTag(tag string) [][]validateRule {
+	levels := [][]validateRule{nil}
+	inKeys := false
+	for _, raw := range strings.Split(tag, ",") {
+		raw, _, _ = strings.Cut(strings.TrimSpace(raw), "|")
+		name, param, _ := strings.Cut(raw, "=")
+		switch {
+		case name == "keys":
+			inKeys = true
+		case name == "endkeys":
+			inKeys = false
+		case inKeys || name == "":
+		case name == "dive":
+			levels = append(levels, nil)
+		default:
+			levels[len(levels)-1] = append(levels[len(levels)-1], validateRule{name: name, param: param})
+		}
+	}
+	return levels
+}
+
+// validateOptions translates the validate tag of a field of type t into options.
+// If violate is not empty, the rule with that name is inverted instead of applied.
+func validateOptions(name string, t reflect.Type, tag string, violate string) (*fieldOptions, error) {
+	root := &fieldOptions{name: name}
+	opts := root
+	violated := false
+	for i, rules := range parseValidateTag(tag) {
+		t = derefType(t)
+		if i > 0 {
+			switch t.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				t = derefType(t.Elem())
+			default:
+				return nil, opts.errorf("dive does not apply to %s", t)
+			}
+			opts.dive = &fieldOptions{name: name}
+			opts = opts.dive
+		}
+
+		var target *validateRule
+		for _, rule := range rules {
+			if rule.name == violate && !violated {
+				target = &rule
+				violated = true
+				continue
+			}
+			if err := opts.applyRule(rule, t); err != nil {
+				return nil, err
+			}
+		}
+		if target != nil {
+			if err := opts.violateRule(*target, t); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if violate != "" && !violated {
+		return nil, errors.Join(ErrUnviolatableValidation, fmt.Errorf("field %s has no %q rule", name, violate))
+	}
+	return root, nil
+}
+
+// applyRule sets the options satisfying rule, for values of type t.
+// Rules without an equivalent, like custom validations, are ignored.
+func (o *fieldOptions) applyRule(rule validateRule, t reflect.Type) error {
+	counted, numeric := isCounted(t), isNumeric(t)
+	switch rule.name {
+	case "required":
+		if !counted || t.Kind() == reflect.String {
+			o.nonZero = true
+		}
+	case "len", "min", "gte", "max", "lte", "gt", "lt":
+		if counted {
+			n, err := strconv.Atoi(rule.param)
+			if err != nil {
+				return o.errorf("validate %s: %v", rule.name, err)
+			}
+			switch rule.name {
+			case "len":
+				o.Length = &n
+			case "min", "gte":
+				o.MinLen = &n
+			case "max", "lte":
+				o.MaxLen = &n
+			case "gt":
+				n++
+				o.MinLen = &n
+			case "lt":
+				if n == 0 {
+					return o.errorf("validate lt=0 cannot be satisfied")
+				}
+				n--
+				o.MaxLen = &n
+			}
+			return nil
+		}
+		if !numeric {
+			return nil
+		}
+		switch rule.name {
+		case "len":
+			o.Oneof = []string{rule.param}
+		case "min", "gte":
+			o.Min = &rule.param
+		case "max", "lte":
+			o.Max = &rule.param
+		case "gt", "lt":
+			delta := 1
+			if rule.name == "lt" {
+				delta = -1
+			}
+			bound, err := shiftBound(t, rule.param, delta)
+			if err != nil {
+				return o.errorf("validate %s: %v", rule.name, err)
+			}
+			if rule.name == "gt" {
+				o.Min = &bound
+			} else {
+				o.Max = &bound
+			}
+		}
+	case "eq":
+		if t.Kind() == reflect.String || numeric {
+			o.Oneof = []string{rule.param}
+		}
+	case "ne":
+		if t.Kind() == reflect.String || numeric {
+			o.exclude = append(o.exclude, rule.param)
+		}
+	case "oneof":
+		o.Oneof = strings.Fields(rule.param)
+	default:
+		if format, ok := validateFormats[rule.name]; ok && t.Kind() == reflect.String {
+			format.apply(o)
+		}
+	}
+	return nil
+}
+
+// violateRule sets the options violating rule, for values of type t.
+// The other rules must already be applied, so the options keep satisfying them.
+func (o *fieldOptions) violateRule(rule validateRule, t reflect.Type) error {
+	unviolatable := func(reason string) error {
+		return errors.Join(ErrUnviolatableValidation, fmt.Errorf("field %s: cannot violate %s: %s", o.name, rule.name, reason))
+	}
+	counted, numeric := isCounted(t), isNumeric(t)
+
+	switch rule.name {
+	case "required":
+		o.zero = true
+		return nil
+	case "eq":
+		o.exclude = append(o.exclude, rule.param)
+		return nil
+	case "ne":
+		o.Oneof = []string{rule.param}
+		return nil
+	case "oneof":
+		o.exclude = append(o.exclude, strings.Fields(rule.param)...)
+		return nil
+	case "len", "min", "gte", "max", "lte", "gt", "lt":
+	default:
+		format, ok := validateFormats[rule.name]
+		if !ok || t.Kind() != reflect.String {
+			return unviolatable(fmt.Sprintf("unsupported rule on %s", t))
+		}
+		o.Charset = &format.violation
+		return nil
+	}
+
+	if numeric {
+		var err error
+		var bound string
+		switch rule.name {
+		case "len":
+			o.exclude = append(o.exclude, rule.param)
+		case "min", "gte":
+			bound, err = shiftBound(t, rule.param, -1)
+			o.Max = &bound
+		case "max", "lte":
+			bound, err = shiftBound(t, rule.param, 1)
+			o.Min = &bound
+		case "gt":
+			o.Max = &rule.param
+		case "lt":
+			o.Min = &rule.param
+		}
+		if err != nil {
+			return unviolatable(err.Error())
+		}
+		return nil
+	}
+	if !counted {
+		return unviolatable(fmt.Sprintf("unsupported rule on %s", t))
+	}
+
+	n, err := strconv.Atoi(rule.param)
+	if err != nil {
+		return o.errorf("validate %s: %v", rule.name, err)
+	}
+	lo, hi, bounded := 0, 0, o.MaxLen != nil
+	if o.nonZero {
+		lo = 1
+	}
+	if o.MinLen != nil {
+		lo = max(lo, *o.MinLen)
+	}
+	if bounded {
+		hi = *o.MaxLen
+	}
+	switch rule.name {
+	case "len":
+		if !bounded || hi > n {
+			lo, hi = n+1, n+1
+		} else {
+			lo, hi = n-1, n-1
+		}
+		bounded = true
+	case "min", "gte":
+		hi, bounded = n-1, true
+	case "gt":
+		hi, bounded = n, true
+	case "max", "lte":
+		lo = max(lo, n+1)
+	case "lt":
+		lo = max(lo, n)
+	}
+	if !bounded {
+		hi = lo + tags.MaxLen
+	}
+	if lo > hi || hi < 0 {
+		return unviolatable("the other rules forbid every other length")
+	}
+	o.Length, o.MinLen, o.MaxLen = nil, &lo, &hi
+	return nil
+}
+
+// shiftBound returns the bound raw of values of type t, moved to the next value in the direction of delta.
+func shiftBound(t reflect.Type, raw string, delta int) (string, error) {
+	v, err := parseValue(t, raw)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case t == durationType:
+		return time.Duration(v.Int() + int64(delta)).String(), nil
+	case isInt(t.Kind()):
+		lo, hi := intBounds(t)
+		if delta < 0 && v.Int() == lo || delta > 0 && v.Int() == hi {
+			return "", fmt.Errorf("no %s beyond %s", t, raw)
+		}
+		return strconv.FormatInt(v.Int()+int64(delta), 10), nil
+	case isUint(t.Kind()):
+		if delta < 0 && v.Uint() == 0 || delta > 0 && v.Uint() == uintMax(t) {
+			return "", fmt.Errorf("no %s beyond %s", t, raw)
+		}
+		if delta < 0 {
+			return strconv.FormatUint(v.Uint()-1, 10), nil
+		}
+		return strconv.FormatUint(v.Uint()+1, 10), nil
+	case t.Kind() == reflect.Float32:
+		return strconv.FormatFloat(float64(math.Nextafter32(float32(v.Float()), float32(math.Inf(delta)))), 'g', -1, 32), nil
+	default:
+		return strconv.FormatFloat(math.Nextafter(v.Float(), math.Inf(delta)), 'g', -1, 64), nil
+	}
+}
+
+// derefType returns the type pointed to by t, through any number of pointers.
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// isCounted reports whether validator rules like min and max apply to the length of values of type t.
+func isCounted(t reflect.Type) bool {
+	return t.Kind() == reflect.String || t.Kind() == reflect.Slice || t.Kind() == reflect.Map
+}
+
+// isNumeric reports whether validator rules like min and max apply to values of type t.
+func isNumeric(t reflect.Type) bool {
+	return isInt(t.Kind()) || isUint(t.Kind()) || t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64
+}
@@ -0,0 +1,195 @@
+package chaos_test
+
+import (
+	"net"
+	"net/mail"
+	"net/netip"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/raphoester/chaos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type signupRequest struct {
+	Username string            `validate:"required,alphanum,min=3,max=12"`
+	Email    string            `validate:"required,email"`
+	Contact  string            `validate:"required,email,min=3,max=30"`
+	Age      int               `validate:"gte=18,lt=130"`
+	Plan     string            `validate:"oneof=free pro enterprise"`
+	Referrer *string           `validate:"omitempty,uuid4"`
+	IP       string            `validate:"ip"`
+	MAC      string            `validate:"mac"`
+	Ratio    float32           `validate:"gt=0,lte=1"`
+	Retries  uint8             `validate:"required,max=5"`
+	Tags     []string          `validate:"min=1,max=3,dive,lowercase,len=4"`
+	Labels   map[string]string `validate:"dive,keys,alpha,endkeys,numeric"`
+	Country  string            `validate:"len=2" chaos:"oneof=FR DE"`
+	Custom   string            `validate:"is_awesome"`
+}
+
+func TestFillValidate(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		var r1, r2 signupRequest
+		require.NoError(t, chaos.New(t.Name()).Fill(&r1))
+		require.NoError(t, chaos.New(t.Name()).Fill(&r2))
+		assert.Equal(t, r1, r2)
+	})
+
+	t.Run("passes validation rules", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 100; i++ {
+			var r signupRequest
+			require.NoError(t, c.Fill(&r))
+			assert.Regexp(t, `^[a-zA-Z0-9]{3,12}$`, r.Username)
+			_, err := mail.ParseAddress(r.Email)
+			assert.NoError(t, err)
+			_, err = mail.ParseAddress(r.Contact)
+			assert.NoError(t, err)
+			assert.GreaterOrEqual(t, utf8.RuneCountInString(r.Contact), 3)
+			assert.LessOrEqual(t, utf8.RuneCountInString(r.Contact), 30)
+			assert.GreaterOrEqual(t, r.Age, 18)
+			assert.Less(t, r.Age, 130)
+			assert.Contains(t, []string{"free", "pro", "enterprise"}, r.Plan)
+			require.NotNil(t, r.Referrer)
+			assert.Equal(t, uuid.Version(4), uuid.MustParse(*r.Referrer).Version())
+			_, err = netip.ParseAddr(r.IP)
+			assert.NoError(t, err)
+			_, err = net.ParseMAC(r.MAC)
+			assert.NoError(t, err)
+			assert.Greater(t, r.Ratio, float32(0))
+			assert.LessOrEqual(t, r.Ratio, float32(1))
+			assert.NotZero(t, r.Retries)
+			assert.LessOrEqual(t, r.Retries, uint8(5))
+			assert.GreaterOrEqual(t, len(r.Tags), 1)
+			assert.LessOrEqual(t, len(r.Tags), 3)
+			for _, tag := range r.Tags {
+				assert.Regexp(t, `^[a-z]{4}$`, tag)
+			}
+			for _, label := range r.Labels {
+				assert.Regexp(t, `^[0-9]+$`, label)
+			}
+			assert.Contains(t, []string{"FR", "DE"}, r.Country)
+			assert.NotEmpty(t, r.Custom)
+		}
+	})
+
+	t.Run("returns error for lengths the format cannot meet", func(t *testing.T) {
+		type request struct {
+			Email string `validate:"email,max=3"`
+		}
+		c := chaos.New(t.Name())
+		var r request
+		assert.ErrorIs(t, c.Fill(&r), chaos.ErrInvalidTag)
+	})
+}
+
+func TestFillViolating(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		var r1, r2 signupRequest
+		require.NoError(t, chaos.New(t.Name()).FillViolating(&r1, "Email", "email"))
+		require.NoError(t, chaos.New(t.Name()).FillViolating(&r2, "Email", "email"))
+		assert.Equal(t, r1, r2)
+	})
+
+	t.Run("violates exactly the chosen rule", func(t *testing.T) {
+		cases := []struct {
+			field, rule string
+			check       func(t *testing.T, r signupRequest)
+		}{
+			{"Username", "required", func(t *testing.T, r signupRequest) {
+				assert.Empty(t, r.Username)
+			}},
+			{"Username", "alphanum", func(t *testing.T, r signupRequest) {
+				assert.NotRegexp(t, `^[a-zA-Z0-9]*$`, r.Username)
+				assert.GreaterOrEqual(t, len(r.Username), 3)
+				assert.LessOrEqual(t, len(r.Username), 12)
+			}},
+			{"Username", "min", func(t *testing.T, r signupRequest) {
+				assert.Regexp(t, `^[a-zA-Z0-9]{1,2}$`, r.Username)
+			}},
+			{"Username", "max", func(t *testing.T, r signupRequest) {
+				assert.Regexp(t, `^[a-zA-Z0-9]{13,}$`, r.Username)
+			}},
+			{"Email", "email", func(t *testing.T, r signupRequest) {
+				_, err := mail.ParseAddress(r.Email)
+				assert.Error(t, err)
+				assert.NotEmpty(t, r.Email)
+			}},
+			{"Age", "gte", func(t *testing.T, r signupRequest) {
+				assert.Less(t, r.Age, 18)
+			}},
+			{"Age", "lt", func(t *testing.T, r signupRequest) {
+				assert.GreaterOrEqual(t, r.Age, 130)
+			}},
+			{"Plan", "oneof", func(t *testing.T, r signupRequest) {
+				assert.NotContains(t, []string{"free", "pro", "enterprise"}, r.Plan)
+			}},
+			{"Referrer", "uuid4", func(t *testing.T, r signupRequest) {
+				require.NotNil(t, r.Referrer)
+				_, err := uuid.Parse(*r.Referrer)
+				assert.Error(t, err)
+			}},
+			{"Ratio", "gt", func(t *testing.T, r signupRequest) {
+				assert.LessOrEqual(t, r.Ratio, float32(0))
+			}},
+			{"Retries", "max", func(t *testing.T, r signupRequest) {
+				assert.Greater(t, r.Retries, uint8(5))
+			}},
+			{"Retries", "required", func(t *testing.T, r signupRequest) {
+				assert.Zero(t, r.Retries)
+			}},
+			{"Tags", "min", func(t *testing.T, r signupRequest) {
+				assert.Empty(t, r.Tags)
+			}},
+			{"Tags", "len", func(t *testing.T, r signupRequest) {
+				require.NotEmpty(t, r.Tags)
+				for _, tag := range r.Tags {
+					assert.NotEqual(t, 4, utf8.RuneCountInString(tag))
+					assert.Regexp(t, `^[a-z]*$`, tag)
+				}
+			}},
+			{"Country", "len", func(t *testing.T, r signupRequest) {
+				assert.Len(t, r.Country, 3)
+			}},
+		}
+		for _, tc := range cases {
+			t.Run(tc.field+" "+tc.rule, func(t *testing.T) {
+				c := chaos.New(t.Name())
+				for i := 0; i < 20; i++ {
+					var r signupRequest
+					require.NoError(t, c.FillViolating(&r, tc.field, tc.rule))
+					tc.check(t, r)
+					if tc.field != "Email" {
+						assert.Contains(t, r.Email, "@", "other fields stay valid")
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("returns error for unknown fields", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		var r signupRequest
+		assert.ErrorIs(t, c.FillViolating(&r, "Password", "required"), chaos.ErrUnknownField)
+	})
+
+	t.Run("returns error for rules the field does not have", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		var r signupRequest
+		assert.ErrorIs(t, c.FillViolating(&r, "Email", "uuid"), chaos.ErrUnviolatableValidation)
+	})
+
+	t.Run("returns error for rules that cannot be violated alone", func(t *testing.T) {
+		type request struct {
+			Name  string `validate:"required,min=1"`
+			Count uint   `validate:"min=0"`
+		}
+		c := chaos.New(t.Name())
+		var r request
+		assert.ErrorIs(t, c.FillViolating(&r, "Name", "min"), chaos.ErrUnviolatableValidation)
+		assert.ErrorIs(t, c.FillViolating(&r, "Count", "min"), chaos.ErrUnviolatableValidation)
+	})
+}
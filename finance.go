@@ -0,0 +1,397 @@
+package chaos
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CardNetwork is a payment card network.
+type CardNetwork int
+
+const (
+	Visa CardNetwork = iota
+	Mastercard
+	AmericanExpress
+	Discover
+	JCB
+	DinersClub
+)
+
+type cardPrefixRange struct {
+	low, high int
+}
+
+type cardNetworkSpec struct {
+	name      string
+	prefixes  []cardPrefixRange
+	length    int
+	cvvLength int
+}
+
+var cardNetworks = map[CardNetwork]cardNetworkSpec{
+	Visa: {
+		name:      "Visa",
+		prefixes:  []cardPrefixRange{{4, 4}},
+		length:    16,
+		cvvLength: 3,
+	},
+	Mastercard: {
+		name:      "Mastercard",
+		prefixes:  []cardPrefixRange{{51, 55}, {2221, 2720}},
+		length:    16,
+		cvvLength: 3,
+	},
+	AmericanExpress: {
+		name:      "American Express",
+		prefixes:  []cardPrefixRange{{34, 34}, {37, 37}},
+		length:    15,
+		cvvLength: 4,
+	},
+	Discover: {
+		name:      "Discover",
+		prefixes:  []cardPrefixRange{{6011, 6011}, {644, 649}, {65, 65}},
+		length:    16,
+		cvvLength: 3,
+	},
+	JCB: {
+		name:      "JCB",
+		prefixes:  []cardPrefixRange{{3528, 3589}},
+		length:    16,
+		cvvLength: 3,
+	},
+	DinersClub: {
+		name:      "Diners Club",
+		prefixes:  []cardPrefixRange{{300, 305}, {36, 36}, {38, 38}},
+		length:    14,
+		cvvLength: 3,
+	},
+}
+
+// String returns the name of the card network.
+func (n CardNetwork) String() string {
+	spec, ok := cardNetworks[n]
+	if !ok {
+		return "CardNetwork(" + strconv.Itoa(int(n)) + ")"
+	}
+	return spec.name
+}
+
+func (n CardNetwork) spec() cardNetworkSpec {
+	spec, ok := cardNetworks[n]
+	if !ok {
+		return cardNetworks[Visa]
+	}
+	return spec
+}
+
+// digits returns a string of n random decimal digits.
+func (c *Chaos) digits(n int) string {
+	r := c.rand()
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		sb.WriteByte(byte('0' + r.Intn(10)))
+	}
+	return sb.String()
+}
+
+// luhnCheckDigit returns the digit that makes payload followed by it pass the Luhn check.
+func luhnCheckDigit(payload string) byte {
+	sum := 0
+	double := true
+	for i := len(payload) - 1; i >= 0; i-- {
+		d := int(payload[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// wrongDigit returns a digit different from d.
+func (c *Chaos) wrongDigit(d byte) byte {
+	return byte('0' + (int(d-'0')+c.IntBetween(1, 9))%10)
+}
+
+// CardNetworks returns all the supported card networks.
+// Use it with SliceItem to pick a random network.
+func CardNetworks() []CardNetwork {
+	return []CardNetwork{Visa, Mastercard, AmericanExpress, Discover, JCB, DinersClub}
+}
+
+// CardNumber returns a random card number passing the Luhn check.
+func CardNumber(network CardNetwork) string {
+	return singleton.CardNumber(network)
+}
+
+// CardNumber returns a deterministic card number of network passing the Luhn check.
+// The number has the issuer prefix and length of the network.
+// Unknown networks are treated as Visa.
+func (c *Chaos) CardNumber(network CardNetwork) string {
+	spec := network.spec()
+	prefixRange := NewSliceProcessor[[]cardPrefixRange](c).Item(spec.prefixes)
+	prefix := strconv.Itoa(c.IntBetween(prefixRange.low, prefixRange.high))
+	payload := prefix + c.digits(spec.length-len(prefix)-1)
+	return payload + string(luhnCheckDigit(payload))
+}
+
+// InvalidCardNumber returns a random card number failing the Luhn check.
+func InvalidCardNumber(network CardNetwork) string {
+	return singleton.InvalidCardNumber(network)
+}
+
+// InvalidCardNumber returns a deterministic card number of network failing the Luhn check.
+// Apart from the check digit, the number is well-formed.
+func (c *Chaos) InvalidCardNumber(network CardNetwork) string {
+	number := []byte(c.CardNumber(network))
+	last := len(number) - 1
+	number[last] = c.wrongDigit(number[last])
+	return string(number)
+}
+
+// CVV returns a random card verification value.
+func CVV(network CardNetwork) string {
+	return singleton.CVV(network)
+}
+
+// CVV returns a deterministic card verification value with the length used by network.
+func (c *Chaos) CVV(network CardNetwork) string {
+	return c.digits(network.spec().cvvLength)
+}
+
+// CardExpiry returns a random card expiry date.
+func CardExpiry(from time.Time) time.Time {
+	return singleton.CardExpiry(from)
+}
+
+// CardExpiry returns a deterministic card expiry date between 1 and 60 months after from.
+// The returned time is the first day of the expiry month, in the location of from.
+func (c *Chaos) CardExpiry(from time.Time) time.Time {
+	month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, from.Location())
+	return month.AddDate(0, c.IntBetween(1, 60), 0)
+}
+
+// CardDetails are the details of a payment card.
+type CardDetails struct {
+	Network CardNetwork
+	Number  string
+	Expiry  time.Time
+	CVV     string
+}
+
+// Card returns a random payment card.
+func Card(network CardNetwork, from time.Time) CardDetails {
+	return singleton.Card(network, from)
+}
+
+// Card returns a deterministic payment card of network, expiring after from.
+func (c *Chaos) Card(network CardNetwork, from time.Time) CardDetails {
+	return CardDetails{
+		Network: network,
+		Number:  c.CardNumber(network),
+		Expiry:  c.CardExpiry(from),
+		CVV:     c.CVV(network),
+	}
+}
+
+var (
+	ErrUnsupportedCountry = errors.New("unsupported country")
+)
+
+// ibanFormats maps country codes to their BBAN structure in the SWIFT notation:
+// "n" is a digit, "a" an upper case letter and "c" an alphanumeric character.
+var ibanFormats = map[string]string{
+	"AT": "5n11n",
+	"BE": "3n7n2n",
+	"CH": "5n12c",
+	"CZ": "4n6n10n",
+	"DE": "8n10n",
+	"DK": "4n9n1n",
+	"ES": "4n4n1n1n10n",
+	"FI": "3n11n",
+	"FR": "5n5n11c2n",
+	"GB": "4a6n8n",
+	"GR": "3n4n16c",
+	"IE": "4a6n8n",
+	"IT": "1a5n5n12c",
+	"LU": "3n13c",
+	"MC": "5n5n11c2n",
+	"NL": "4a10n",
+	"NO": "4n6n1n",
+	"PL": "8n16n",
+	"PT": "4n4n11n2n",
+	"SE": "3n16n1n",
+}
+
+// ibanCountries is the sorted list of keys of ibanFormats.
+var ibanCountries = func() []string {
+	countries := make([]string, 0, len(ibanFormats))
+	for country := range ibanFormats {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+	return countries
+}()
+
+const (
+	upperChars        = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	upperAlphanumeric = upperChars + "0123456789"
+)
+
+func (c *Chaos) bban(format string) string {
+	r := c.rand()
+	var sb strings.Builder
+	count := 0
+	for _, ch := range format {
+		if ch >= '0' && ch <= '9' {
+			count = count*10 + int(ch-'0')
+			continue
+		}
+		charset := "0123456789"
+		switch ch {
+		case 'a':
+			charset = upperChars
+		case 'c':
+			charset = upperAlphanumeric
+		}
+		for i := 0; i < count; i++ {
+			sb.WriteByte(charset[r.Intn(len(charset))])
+		}
+		count = 0
+	}
+	return sb.String()
+}
+
+// ibanCheckDigits returns the ISO 7064 mod-97 check digits of an IBAN.
+func ibanCheckDigits(country, bban string) int {
+	var numeric strings.Builder
+	for _, ch := range bban + country + "00" {
+		if ch >= 'A' && ch <= 'Z' {
+			numeric.WriteString(strconv.Itoa(int(ch-'A') + 10))
+		} else {
+			numeric.WriteRune(ch)
+		}
+	}
+	n, _ := new(big.Int).SetString(numeric.String(), 10)
+	return 98 - int(new(big.Int).Mod(n, big.NewInt(97)).Int64())
+}
+
+func (c *Chaos) ibanParts(country string) (string, string, error) {
+	country = strings.ToUpper(country)
+	if country == "" {
+		country = NewSliceProcessor[[]string](c).Item(ibanCountries)
+	}
+	format, ok := ibanFormats[country]
+	if !ok {
+		return "", "", errors.Join(ErrUnsupportedCountry,
+			fmt.Errorf("no IBAN format for country %q", country))
+	}
+	return country, c.bban(format), nil
+}
+
+// IBAN returns a random IBAN with valid check digits.
+func IBAN(country string) (string, error) {
+	return singleton.IBAN(country)
+}
+
+// IBAN returns a deterministic IBAN of country with valid mod-97 check digits.
+// Behavior:
+//   - If country is empty, a random supported country is used.
+//   - If the country is not supported, it returns an error.
+func (c *Chaos) IBAN(country string) (string, error) {
+	country, bban, err := c.ibanParts(country)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%02d%s", country, ibanCheckDigits(country, bban), bban), nil
+}
+
+// InvalidIBAN returns a random IBAN with wrong check digits.
+func InvalidIBAN(country string) (string, error) {
+	return singleton.InvalidIBAN(country)
+}
+
+// InvalidIBAN returns a deterministic IBAN of country failing the mod-97 check.
+// Apart from the check digits, the IBAN is well-formed.
+func (c *Chaos) InvalidIBAN(country string) (string, error) {
+	country, bban, err := c.ibanParts(country)
+	if err != nil {
+		return "", err
+	}
+	valid := ibanCheckDigits(country, bban)
+	// check digits range from 02 to 98, so shifting within that range never yields the valid value
+	wrong := (valid-2+c.IntBetween(1, 96))%97 + 2
+	return fmt.Sprintf("%s%02d%s", country, wrong, bban), nil
+}
+
+// BIC returns a random BIC (SWIFT code).
+func BIC() string {
+	return singleton.BIC()
+}
+
+// BIC returns a deterministic BIC (SWIFT code).
+// It is made of a bank code, a country code, a location code and an optional branch code.
+func (c *Chaos) BIC() string {
+	r := c.rand()
+	var sb strings.Builder
+	for i := 0; i < 4; i++ {
+		sb.WriteByte(upperChars[r.Intn(len(upperChars))])
+	}
+	sb.WriteString(ibanCountries[r.Intn(len(ibanCountries))])
+	for i := 0; i < 2; i++ {
+		sb.WriteByte(upperAlphanumeric[r.Intn(len(upperAlphanumeric))])
+	}
+	if r.Intn(2) == 0 {
+		for i := 0; i < 3; i++ {
+			sb.WriteByte(upperAlphanumeric[r.Intn(len(upperAlphanumeric))])
+		}
+	}
+	return sb.String()
+}
+
+// abaCheckDigit returns the check digit of the first 8 digits of an ABA routing number.
+func abaCheckDigit(payload string) byte {
+	weights := [8]int{3, 7, 1, 3, 7, 1, 3, 7}
+	sum := 0
+	for i, w := range weights {
+		sum += int(payload[i]-'0') * w
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// RoutingNumber returns a random ABA routing number.
+func RoutingNumber() string {
+	return singleton.RoutingNumber()
+}
+
+// RoutingNumber returns a deterministic ABA routing number with a valid check digit.
+// The first two digits are a valid Federal Reserve routing symbol (01-12 or 21-32).
+func (c *Chaos) RoutingNumber() string {
+	district := c.IntBetween(1, 24)
+	if district > 12 {
+		district += 8
+	}
+	payload := fmt.Sprintf("%02d", district) + c.digits(6)
+	return payload + string(abaCheckDigit(payload))
+}
+
+// InvalidRoutingNumber returns a random ABA routing number with a wrong check digit.
+func InvalidRoutingNumber() string {
+	return singleton.InvalidRoutingNumber()
+}
+
+// InvalidRoutingNumber returns a deterministic ABA routing number with a wrong check digit.
+func (c *Chaos) InvalidRoutingNumber() string {
+	number := []byte(c.RoutingNumber())
+	number[8] = c.wrongDigit(number[8])
+	return string(number)
+}
@@ -0,0 +1,215 @@
+package chaos_test
+
+import (
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raphoester/chaos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func luhnValid(number string) bool {
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		d := int(number[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+func ibanValid(iban string) bool {
+	rearranged := iban[4:] + iban[:4]
+	var numeric strings.Builder
+	for _, ch := range rearranged {
+		if ch >= 'A' && ch <= 'Z' {
+			numeric.WriteString(strconv.Itoa(int(ch-'A') + 10))
+		} else {
+			numeric.WriteRune(ch)
+		}
+	}
+	n, _ := new(big.Int).SetString(numeric.String(), 10)
+	return new(big.Int).Mod(n, big.NewInt(97)).Int64() == 1
+}
+
+func abaValid(number string) bool {
+	weights := []int{3, 7, 1, 3, 7, 1, 3, 7, 1}
+	sum := 0
+	for i, w := range weights {
+		sum += int(number[i]-'0') * w
+	}
+	return sum%10 == 0
+}
+
+func TestCardNumber(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.CardNumber(chaos.Visa), c.CardNumber(chaos.Visa))
+	})
+
+	t.Run("passes the Luhn check", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for _, network := range chaos.CardNetworks() {
+			for i := 0; i < 100; i++ {
+				number := c.CardNumber(network)
+				assert.True(t, luhnValid(number), "%s: %s", network, number)
+			}
+		}
+	})
+
+	t.Run("respects network prefix and length", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		patterns := map[chaos.CardNetwork]*regexp.Regexp{
+			chaos.Visa:            regexp.MustCompile(`^4\d{15}$`),
+			chaos.Mastercard:      regexp.MustCompile(`^(5[1-5]|2[2-7])\d{14}$`),
+			chaos.AmericanExpress: regexp.MustCompile(`^3[47]\d{13}$`),
+			chaos.Discover:        regexp.MustCompile(`^6\d{15}$`),
+			chaos.JCB:             regexp.MustCompile(`^35\d{14}$`),
+			chaos.DinersClub:      regexp.MustCompile(`^3[068]\d{12}$`),
+		}
+		for network, pattern := range patterns {
+			for i := 0; i < 100; i++ {
+				number := c.CardNumber(network)
+				assert.Regexp(t, pattern, number, network.String())
+			}
+		}
+	})
+
+	t.Run("invalid numbers fail the Luhn check", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for _, network := range chaos.CardNetworks() {
+			for i := 0; i < 100; i++ {
+				number := c.InvalidCardNumber(network)
+				assert.False(t, luhnValid(number), "%s: %s", network, number)
+			}
+		}
+	})
+}
+
+func TestCard(t *testing.T) {
+	from := time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.Card(chaos.Mastercard, from), c.Card(chaos.Mastercard, from))
+	})
+
+	t.Run("expires in the future", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 100; i++ {
+			card := c.Card(chaos.Visa, from)
+			assert.True(t, card.Expiry.After(from))
+			assert.True(t, card.Expiry.Before(from.AddDate(5, 1, 0)))
+			assert.Equal(t, 1, card.Expiry.Day())
+		}
+	})
+
+	t.Run("uses network CVV length", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		assert.Len(t, c.Card(chaos.AmericanExpress, from).CVV, 4)
+		assert.Len(t, c.Card(chaos.Visa, from).CVV, 3)
+	})
+}
+
+func TestIBAN(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		iban1, err1 := c.IBAN("FR")
+		iban2, err2 := c.IBAN("FR")
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.Equal(t, iban1, iban2)
+	})
+
+	t.Run("passes the mod-97 check", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 1000; i++ {
+			iban, err := c.IBAN("")
+			require.NoError(t, err)
+			assert.True(t, ibanValid(iban), iban)
+		}
+	})
+
+	t.Run("respects country format", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		de, err := c.IBAN("de")
+		require.NoError(t, err)
+		assert.Regexp(t, `^DE\d{20}$`, de)
+
+		gb, err := c.IBAN("GB")
+		require.NoError(t, err)
+		assert.Regexp(t, `^GB\d{2}[A-Z]{4}\d{14}$`, gb)
+	})
+
+	t.Run("invalid IBANs fail the mod-97 check", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 1000; i++ {
+			iban, err := c.InvalidIBAN("")
+			require.NoError(t, err)
+			assert.False(t, ibanValid(iban), iban)
+			assert.Regexp(t, `^[A-Z]{2}\d{2}`, iban)
+		}
+	})
+
+	t.Run("returns error for unsupported country", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		_, err := c.IBAN("XX")
+		assert.ErrorIs(t, err, chaos.ErrUnsupportedCountry)
+	})
+}
+
+func TestBIC(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.BIC(), c.BIC())
+	})
+
+	t.Run("respects format", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 100; i++ {
+			assert.Regexp(t, `^[A-Z]{6}[A-Z0-9]{2}([A-Z0-9]{3})?$`, c.BIC())
+		}
+	})
+}
+
+func TestRoutingNumber(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.RoutingNumber(), c.RoutingNumber())
+	})
+
+	t.Run("passes the checksum", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 1000; i++ {
+			number := c.RoutingNumber()
+			require.Len(t, number, 9)
+			assert.True(t, abaValid(number), number)
+			assert.Regexp(t, `^(0[1-9]|1[0-2]|2[1-9]|3[0-2])`, number)
+		}
+	})
+
+	t.Run("invalid numbers fail the checksum", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 1000; i++ {
+			number := c.InvalidRoutingNumber()
+			assert.False(t, abaValid(number), number)
+		}
+	})
+}
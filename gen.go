@@ -0,0 +1,296 @@
+package chaos
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrFilterExhausted = errors.New("filter rejected too many values")
+)
+
+// Gen is a generator of values of type T.
+// Unlike the methods of Chaos, generators are values: they can be stored, passed around
+// and composed with combinators like Map, Filter or OneOf.
+// A generator draws all of its randomness from the Chaos it is given, so it is
+// deterministic for a given seed.
+type Gen[T any] interface {
+	Generate(c *Chaos) T
+}
+
+// GenFunc adapts a function to the Gen interface.
+// Any generator method of Chaos can be used as a GenFunc, for example GenFunc[string]((*Chaos).Email).
+type GenFunc[T any] func(c *Chaos) T
+
+// Generate calls f(c).
+func (f GenFunc[T]) Generate(c *Chaos) T {
+	return f(c)
+}
+
+// Const returns a generator always returning v.
+func Const[T any](v T) Gen[T] {
+	return GenFunc[T](func(*Chaos) T {
+		return v
+	})
+}
+
+// Elements returns a generator returning one of values, each of them being equally likely.
+// If values is empty, the generator returns the zero value.
+func Elements[T any](values ...T) Gen[T] {
+	return GenFunc[T](func(c *Chaos) T {
+		return NewSliceProcessor[[]T](c).Item(values)
+	})
+}
+
+// Map returns a generator applying f to the values generated by g.
+func Map[T, U any](g Gen[T], f func(T) U) Gen[U] {
+	return GenFunc[U](func(c *Chaos) U {
+		return f(g.Generate(c))
+	})
+}
+
+// Filter returns a generator returning the values generated by g that satisfy keep.
+// Rejected values are generated again. If 1000 values in a row are rejected,
+// the generator panics with ErrFilterExhausted: the predicate is too strict for g,
+// and the generator should be rewritten to produce valid values directly.
+func Filter[T any](g Gen[T], keep func(T) bool) Gen[T] {
+	return GenFunc[T](func(c *Chaos) T {
+		for i := 0; i < maxUniqueRetries; i++ {
+			if v := g.Generate(c); keep(v) {
+				return v
+			}
+		}
+		panic(errors.Join(ErrFilterExhausted, fmt.Errorf("%d values rejected in a row", maxUniqueRetries)))
+	})
+}
+
+// FlatMap returns a generator using the values generated by g to choose the generator to draw from.
+// This allows generating dependent values, like a slice and a valid index into it.
+func FlatMap[T, U any](g Gen[T], f func(T) Gen[U]) Gen[U] {
+	return GenFunc[U](func(c *Chaos) U {
+		return f(g.Generate(c)).Generate(c)
+	})
+}
+
+// OneOf returns a generator drawing from one of gens, each of them being equally likely.
+// If gens is empty, the generator returns the zero value.
+func OneOf[T any](gens ...Gen[T]) Gen[T] {
+	return GenFunc[T](func(c *Chaos) T {
+		if len(gens) == 0 {
+			var zero T
+			return zero
+		}
+		return gens[c.Int(len(gens)-1)].Generate(c)
+	})
+}
+
+// Weighted is a generator with a relative weight, see Frequency.
+type Weighted[T any] struct {
+	Weight int
+	Gen    Gen[T]
+}
+
+// Frequency returns a generator drawing from one of choices, with a probability proportional to its weight.
+// Choices with a weight <= 0 are never drawn. If no choice has a positive weight, the generator returns the zero value.
+func Frequency[T any](choices ...Weighted[T]) Gen[T] {
+	total := 0
+	for _, choice := range choices {
+		total += max(choice.Weight, 0)
+	}
+	return GenFunc[T](func(c *Chaos) T {
+		if total == 0 {
+			var zero T
+			return zero
+		}
+		pick := c.Int(total - 1)
+		for _, choice := range choices {
+			if choice.Weight <= 0 {
+				continue
+			}
+			if pick < choice.Weight {
+				return choice.Gen.Generate(c)
+			}
+			pick -= choice.Weight
+		}
+		panic("unreachable")
+	})
+}
+
+// SliceOf returns a generator of slices of minLen to maxLen items generated by g.
+func SliceOf[T any](g Gen[T], minLen, maxLen int) Gen[[]T] {
+	return GenFunc[[]T](func(c *Chaos) []T {
+		ret := []T{}
+		c.repeat(minLen, maxLen, func() {
+			ret = append(ret, g.Generate(c))
+		})
+		return ret
+	})
+}
+
+// StringOf returns a generator of strings of minLen to maxLen characters picked from charset.
+// Unlike StringFromGen, the length varies, so Check can shrink the strings.
+// If charset is empty, the strings are empty.
+func StringOf(charset string, minLen, maxLen int) Gen[string] {
+	chars := []rune(charset)
+	return GenFunc[string](func(c *Chaos) string {
+		if len(chars) == 0 {
+			return ""
+		}
+		ret := []rune{}
+		c.repeat(minLen, maxLen, func() {
+			ret = append(ret, chars[c.Int(len(chars)-1)])
+		})
+		return string(ret)
+	})
+}
+
+// PtrOf returns a generator of pointers to values generated by g.
+// The pointers are never nil, see Optional.
+func PtrOf[T any](g Gen[T]) Gen[*T] {
+	return GenFunc[*T](func(c *Chaos) *T {
+		v := g.Generate(c)
+		return &v
+	})
+}
+
+// Optional returns a generator of pointers to values generated by g, or nil half of the time.
+func Optional[T any](g Gen[T]) Gen[*T] {
+	return GenFunc[*T](func(c *Chaos) *T {
+		if c.Int(1) == 0 {
+			return nil
+		}
+		v := g.Generate(c)
+		return &v
+	})
+}
+
+// Pair holds two values of possibly different types.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip returns a generator of pairs of values generated by ga and gb.
+func Zip[A, B any](ga Gen[A], gb Gen[B]) Gen[Pair[A, B]] {
+	return GenFunc[Pair[A, B]](func(c *Chaos) Pair[A, B] {
+		return Pair[A, B]{
+			First:  ga.Generate(c),
+			Second: gb.Generate(c),
+		}
+	})
+}
+
+// Triple holds three values of possibly different types.
+type Triple[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// Zip3 returns a generator of triples of values generated by ga, gb and gc.
+func Zip3[A, B, C any](ga Gen[A], gb Gen[B], gc Gen[C]) Gen[Triple[A, B, C]] {
+	return GenFunc[Triple[A, B, C]](func(c *Chaos) Triple[A, B, C] {
+		return Triple[A, B, C]{
+			First:  ga.Generate(c),
+			Second: gb.Generate(c),
+			Third:  gc.Generate(c),
+		}
+	})
+}
+
+// FieldGen sets one field of a struct of type T, see StructOf.
+type FieldGen[T any] interface {
+	set(c *Chaos, v *T)
+}
+
+type fieldGen[T, F any] struct {
+	setter func(v *T, f F)
+	gen    Gen[F]
+}
+
+func (f fieldGen[T, F]) set(c *Chaos, v *T) {
+	f.setter(v, f.gen.Generate(c))
+}
+
+// Field returns a FieldGen calling setter with values generated by g.
+func Field[T, F any](setter func(v *T, f F), g Gen[F]) FieldGen[T] {
+	return fieldGen[T, F]{
+		setter: setter,
+		gen:    g,
+	}
+}
+
+// StructOf returns a generator of structs of type T, with fields set in order by fields.
+// Fields that are not listed keep their zero value.
+//
+//	users := chaos.StructOf(
+//		chaos.Field(func(u *User, v string) { u.Email = v }, chaos.EmailGen()),
+//		chaos.Field(func(u *User, v int) { u.Age = v }, chaos.IntGen(18, 99)),
+//	)
+func StructOf[T any](fields ...FieldGen[T]) Gen[T] {
+	return GenFunc[T](func(c *Chaos) T {
+		var v T
+		for _, field := range fields {
+			field.set(c, &v)
+		}
+		return v
+	})
+}
+
+// defaultSize is the size of a chaos that was not resized, see Sized.
+const defaultSize = 30
+
+// Size returns the size of the values generated by sized generators with c, see Sized.
+func (c *Chaos) Size() int {
+	return c.size
+}
+
+// Sized returns a generator built by f from the size of the chaos, 30 unless it is changed by Resize.
+// The size bounds the values generated, like the number of items of slices:
+//
+//	lists := chaos.Sized(func(size int) chaos.Gen[[]int] {
+//		return chaos.SliceOf(chaos.IntGen(0, 9), 0, size)
+//	})
+func Sized[T any](f func(size int) Gen[T]) Gen[T] {
+	return GenFunc[T](func(c *Chaos) T {
+		return f(c.Size()).Generate(c)
+	})
+}
+
+// Resize returns a generator running g with the size of the chaos set to size.
+// Negative sizes are treated as 0.
+func Resize[T any](g Gen[T], size int) Gen[T] {
+	return GenFunc[T](func(c *Chaos) T {
+		prev := c.size
+		c.size = max(size, 0)
+		defer func() { c.size = prev }()
+		return g.Generate(c)
+	})
+}
+
+// Recursive returns a generator of recursive values, like trees.
+// node returns the generator of inner values from the generator of their children.
+// The size of the chaos is halved at each level, and a leaf is generated with probability
+// 1/(size+1), so values are at most log2(size)+2 levels deep:
+//
+//	trees := chaos.Recursive(chaos.Const[*Node](nil), func(child chaos.Gen[*Node]) chaos.Gen[*Node] {
+//		return chaos.PtrOf(chaos.StructOf(
+//			chaos.Field(func(n *Node, v []*Node) { n.Children = v }, chaos.SliceOf(child, 0, 3)),
+//		))
+//	})
+func Recursive[T any](leaf Gen[T], node func(child Gen[T]) Gen[T]) Gen[T] {
+	var self GenFunc[T]
+	self = func(c *Chaos) T {
+		size := c.Size()
+		if size == 0 || c.Int(size) == 0 {
+			return leaf.Generate(c)
+		}
+		return Resize(node(self), size/2).Generate(c)
+	}
+	return self
+}
+
+// Draw returns a value generated by g from the singleton chaos.
+func Draw[T any](g Gen[T]) T {
+	return g.Generate(singleton)
+}
@@ -0,0 +1,346 @@
+package chaos
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// This file adapts the generator methods of Chaos to the Gen interface.
+// Adapters take the same arguments as the methods they wrap, except that integer,
+// float and duration adapters always take a range, like IntBetween.
+// Methods that can fail have adapters panicking on error, in the same way as
+// regexp.MustCompile: the arguments are usually constants, so an error is a programming mistake.
+
+// must panics if err is not nil.
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// IntGen returns a generator of ints between min and max included.
+func IntGen(min, max int) Gen[int] {
+	return GenFunc[int](func(c *Chaos) int {
+		return c.IntBetween(min, max)
+	})
+}
+
+// Int32Gen returns a generator of int32s between min and max included.
+func Int32Gen(min, max int32) Gen[int32] {
+	return GenFunc[int32](func(c *Chaos) int32 {
+		return c.Int32Between(min, max)
+	})
+}
+
+// Int64Gen returns a generator of int64s between min and max included.
+func Int64Gen(min, max int64) Gen[int64] {
+	return GenFunc[int64](func(c *Chaos) int64 {
+		return c.Int64Between(min, max)
+	})
+}
+
+// Float32Gen returns a generator of float32s between min and max.
+func Float32Gen(min, max float32) Gen[float32] {
+	return GenFunc[float32](func(c *Chaos) float32 {
+		return c.Float32Between(min, max)
+	})
+}
+
+// Float64Gen returns a generator of float64s between min and max.
+func Float64Gen(min, max float64) Gen[float64] {
+	return GenFunc[float64](func(c *Chaos) float64 {
+		return c.Float64Between(min, max)
+	})
+}
+
+// BoolGen returns a generator of booleans.
+func BoolGen() Gen[bool] {
+	return GenFunc[bool]((*Chaos).Bool)
+}
+
+// DurationGen returns a generator of durations between min and max.
+func DurationGen(min, max time.Duration) Gen[time.Duration] {
+	return GenFunc[time.Duration](func(c *Chaos) time.Duration {
+		return c.DurationBetween(min, max)
+	})
+}
+
+// TimeGen returns a generator of times between Unix epoch and 2106-02-07 08:28:16.
+func TimeGen() Gen[time.Time] {
+	return GenFunc[time.Time]((*Chaos).Time)
+}
+
+// TimeBetweenGen returns a generator of times between min and max.
+func TimeBetweenGen(min, max time.Time) Gen[time.Time] {
+	return GenFunc[time.Time](func(c *Chaos) time.Time {
+		return c.TimeBetween(min, max)
+	})
+}
+
+// StringGen returns a generator of strings of <length> alphanumerical characters.
+func StringGen(length int) Gen[string] {
+	return GenFunc[string](func(c *Chaos) string {
+		return c.String(length)
+	})
+}
+
+// StringFromGen returns a generator of strings of <length> characters picked from charset.
+func StringFromGen(charset string, length int) Gen[string] {
+	return GenFunc[string](func(c *Chaos) string {
+		return c.StringFrom(charset, length)
+	})
+}
+
+// RegexGen returns a generator of strings matching pattern, see Regex.
+// The generator panics if the pattern is invalid.
+func RegexGen(pattern string) Gen[string] {
+	return GenFunc[string](func(c *Chaos) string {
+		return must(c.Regex(pattern))
+	})
+}
+
+// FakerGen returns a generator of the strings of the named faker, like the faker option of Fill.
+// It panics if the faker is unknown, see Fakers.
+func FakerGen(name string) Gen[string] {
+	gen, ok := fillFakers[name]
+	if !ok {
+		panic(fmt.Sprintf("chaos: unknown faker %q", name))
+	}
+	return GenFunc[string](gen)
+}
+
+// Fakers returns the sorted names of the fakers of FakerGen and of the faker option of Fill.
+func Fakers() []string {
+	names := make([]string, 0, len(fillFakers))
+	for name := range fillFakers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// BytesGen returns a generator of slices of n bytes.
+func BytesGen(n int) Gen[[]byte] {
+	return GenFunc[[]byte](func(c *Chaos) []byte {
+		return c.Bytes(n)
+	})
+}
+
+// UUIDGen returns a generator of random UUIDs.
+func UUIDGen() Gen[uuid.UUID] {
+	return GenFunc[uuid.UUID]((*Chaos).UUID)
+}
+
+// UUIDv7Gen returns a generator of version 7 UUIDs embedding at.
+func UUIDv7Gen(at time.Time) Gen[uuid.UUID] {
+	return GenFunc[uuid.UUID](func(c *Chaos) uuid.UUID {
+		return c.UUIDv7(at)
+	})
+}
+
+// ULIDGen returns a generator of ULIDs.
+func ULIDGen() Gen[string] {
+	return GenFunc[string]((*Chaos).ULID)
+}
+
+// KSUIDGen returns a generator of KSUIDs.
+func KSUIDGen() Gen[string] {
+	return GenFunc[string]((*Chaos).KSUID)
+}
+
+// SnowflakeGen returns a generator of Snowflake IDs of nodeID.
+func SnowflakeGen(nodeID int64) Gen[int64] {
+	return GenFunc[int64](func(c *Chaos) int64 {
+		return c.Snowflake(nodeID)
+	})
+}
+
+// NanoIDGen returns a generator of NanoIDs of size characters picked from alphabet.
+func NanoIDGen(size int, alphabet string) Gen[string] {
+	return GenFunc[string](func(c *Chaos) string {
+		return c.NanoID(size, alphabet)
+	})
+}
+
+// ObjectIDGen returns a generator of MongoDB ObjectIDs.
+func ObjectIDGen() Gen[string] {
+	return GenFunc[string]((*Chaos).ObjectID)
+}
+
+// ShortCodeGen returns a generator of Crockford base32 codes of length characters.
+func ShortCodeGen(length int) Gen[string] {
+	return GenFunc[string](func(c *Chaos) string {
+		return c.ShortCode(length)
+	})
+}
+
+// IPv4Gen returns a generator of IPv4 addresses in scope.
+func IPv4Gen(scope IPScope) Gen[netip.Addr] {
+	return GenFunc[netip.Addr](func(c *Chaos) netip.Addr {
+		return c.IPv4In(scope)
+	})
+}
+
+// IPv6Gen returns a generator of IPv6 addresses in scope.
+func IPv6Gen(scope IPScope) Gen[netip.Addr] {
+	return GenFunc[netip.Addr](func(c *Chaos) netip.Addr {
+		return c.IPv6In(scope)
+	})
+}
+
+// IPInPrefixGen returns a generator of addresses in p.
+func IPInPrefixGen(p netip.Prefix) Gen[netip.Addr] {
+	return GenFunc[netip.Addr](func(c *Chaos) netip.Addr {
+		return c.IPInPrefix(p)
+	})
+}
+
+// PrefixGen returns a generator of masked IPv4 prefixes.
+func PrefixGen() Gen[netip.Prefix] {
+	return GenFunc[netip.Prefix]((*Chaos).Prefix)
+}
+
+// MACGen returns a generator of MAC addresses.
+func MACGen() Gen[net.HardwareAddr] {
+	return GenFunc[net.HardwareAddr]((*Chaos).MAC)
+}
+
+// PortGen returns a generator of port numbers.
+func PortGen() Gen[uint16] {
+	return GenFunc[uint16]((*Chaos).Port)
+}
+
+// DomainGen returns a generator of domain names under tld.
+func DomainGen(tld string) Gen[string] {
+	return GenFunc[string](func(c *Chaos) string {
+		return c.Domain(tld)
+	})
+}
+
+// HostnameGen returns a generator of hostnames.
+func HostnameGen() Gen[string] {
+	return GenFunc[string]((*Chaos).Hostname)
+}
+
+// EmailGen returns a generator of email addresses.
+func EmailGen() Gen[string] {
+	return GenFunc[string]((*Chaos).Email)
+}
+
+// URLGen returns a generator of URLs shaped by opts.
+func URLGen(opts URLOptions) Gen[string] {
+	return GenFunc[string](func(c *Chaos) string {
+		return c.URL(opts)
+	})
+}
+
+// UserAgentGen returns a generator of browser user agents.
+func UserAgentGen() Gen[string] {
+	return GenFunc[string]((*Chaos).UserAgent)
+}
+
+// CardNumberGen returns a generator of valid card numbers of network.
+func CardNumberGen(network CardNetwork) Gen[string] {
+	return GenFunc[string](func(c *Chaos) string {
+		return c.CardNumber(network)
+	})
+}
+
+// CardGen returns a generator of payment cards of network, expiring after from.
+func CardGen(network CardNetwork, from time.Time) Gen[CardDetails] {
+	return GenFunc[CardDetails](func(c *Chaos) CardDetails {
+		return c.Card(network, from)
+	})
+}
+
+// IBANGen returns a generator of valid IBANs of country.
+// The generator panics if the country is not supported.
+func IBANGen(country string) Gen[string] {
+	return GenFunc[string](func(c *Chaos) string {
+		return must(c.IBAN(country))
+	})
+}
+
+// BICGen returns a generator of BICs.
+func BICGen() Gen[string] {
+	return GenFunc[string]((*Chaos).BIC)
+}
+
+// RoutingNumberGen returns a generator of valid ABA routing numbers.
+func RoutingNumberGen() Gen[string] {
+	return GenFunc[string]((*Chaos).RoutingNumber)
+}
+
+// CurrencyGen returns a generator of ISO 4217 currencies.
+func CurrencyGen() Gen[ISOCurrency] {
+	return GenFunc[ISOCurrency]((*Chaos).Currency)
+}
+
+// MoneyGen returns a generator of amounts of currencyCode between min and max.
+// The generator panics if the currency is unknown.
+func MoneyGen(currencyCode string, min, max float64) Gen[Amount] {
+	return GenFunc[Amount](func(c *Chaos) Amount {
+		return must(c.Money(currencyCode, min, max))
+	})
+}
+
+// PasswordGen returns a generator of passwords satisfying policy.
+// The generator panics if the policy cannot be satisfied.
+func PasswordGen(policy PasswordPolicy) Gen[string] {
+	return GenFunc[string](func(c *Chaos) string {
+		return must(c.Password(policy))
+	})
+}
+
+// HexTokenGen returns a generator of tokens of n bytes encoded in hexadecimal.
+func HexTokenGen(n int) Gen[string] {
+	return GenFunc[string](func(c *Chaos) string {
+		return c.HexToken(n)
+	})
+}
+
+// APIKeyGen returns a generator of API keys starting with prefix.
+func APIKeyGen(prefix string, length int) Gen[string] {
+	return GenFunc[string](func(c *Chaos) string {
+		return c.APIKey(prefix, length)
+	})
+}
+
+// WordGen returns a generator of lorem ipsum words.
+func WordGen() Gen[string] {
+	return GenFunc[string]((*Chaos).Word)
+}
+
+// SentenceGen returns a generator of lorem ipsum sentences of minWords to maxWords words.
+func SentenceGen(minWords, maxWords int) Gen[string] {
+	return GenFunc[string](func(c *Chaos) string {
+		return c.Sentence(minWords, maxWords)
+	})
+}
+
+// ParagraphGen returns a generator of lorem ipsum paragraphs of n sentences.
+func ParagraphGen(n int) Gen[string] {
+	return GenFunc[string](func(c *Chaos) string {
+		return c.Paragraph(n)
+	})
+}
+
+// TextGen returns a generator of lorem ipsum texts of at most maxChars characters.
+func TextGen(maxChars int) Gen[string] {
+	return GenFunc[string](func(c *Chaos) string {
+		return c.Text(maxChars)
+	})
+}
+
+// TitleGen returns a generator of titles of minWords to maxWords words.
+func TitleGen(minWords, maxWords int) Gen[string] {
+	return GenFunc[string](func(c *Chaos) string {
+		return c.Title(minWords, maxWords)
+	})
+}
@@ -0,0 +1,295 @@
+package chaos_test
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/raphoester/chaos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGen(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		g := chaos.SliceOf(chaos.IntGen(0, 100), 5, 10)
+		assert.Equal(t, g.Generate(chaos.New(t.Name())), g.Generate(chaos.New(t.Name())))
+	})
+
+	t.Run("adapters match their methods", func(t *testing.T) {
+		assert.Equal(t, chaos.New(t.Name()).Email(), chaos.EmailGen().Generate(chaos.New(t.Name())))
+		assert.Equal(t, chaos.New(t.Name()).IntBetween(3, 7), chaos.IntGen(3, 7).Generate(chaos.New(t.Name())))
+		assert.Equal(t, chaos.New(t.Name()).String(8), chaos.StringGen(8).Generate(chaos.New(t.Name())))
+	})
+
+	t.Run("methods are generators", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		g := chaos.GenFunc[string]((*chaos.Chaos).Hostname)
+		assert.NotEmpty(t, g.Generate(c))
+	})
+
+	t.Run("adapters panic on invalid arguments", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		assert.Panics(t, func() { chaos.IBANGen("XX").Generate(c) })
+		assert.Panics(t, func() { chaos.MoneyGen("XXX", 0, 1).Generate(c) })
+	})
+}
+
+func TestMap(t *testing.T) {
+	c := chaos.New(t.Name())
+	g := chaos.Map(chaos.IntGen(0, 100), strconv.Itoa)
+	for i := 0; i < 100; i++ {
+		n, err := strconv.Atoi(g.Generate(c))
+		require.NoError(t, err)
+		assert.LessOrEqual(t, n, 100)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	t.Run("only returns accepted values", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		even := chaos.Filter(chaos.IntGen(0, 100), func(n int) bool { return n%2 == 0 })
+		for i := 0; i < 100; i++ {
+			assert.Zero(t, even.Generate(c)%2)
+		}
+	})
+
+	t.Run("panics when every value is rejected", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		never := chaos.Filter(chaos.IntGen(0, 100), func(int) bool { return false })
+		assert.PanicsWithError(t,
+			"filter rejected too many values\n1000 values rejected in a row",
+			func() { never.Generate(c) })
+	})
+}
+
+func TestFlatMap(t *testing.T) {
+	c := chaos.New(t.Name())
+	g := chaos.FlatMap(chaos.SliceOf(chaos.WordGen(), 1, 5), func(words []string) chaos.Gen[chaos.Pair[[]string, int]] {
+		return chaos.Zip(chaos.Const(words), chaos.IntGen(0, len(words)-1))
+	})
+	for i := 0; i < 100; i++ {
+		p := g.Generate(c)
+		assert.Less(t, p.Second, len(p.First))
+	}
+}
+
+func TestOneOf(t *testing.T) {
+	t.Run("draws from every generator", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		g := chaos.OneOf(chaos.Const("a"), chaos.Const("b"), chaos.Const("c"))
+		seen := make(map[string]bool)
+		for i := 0; i < 100; i++ {
+			seen[g.Generate(c)] = true
+		}
+		assert.Len(t, seen, 3)
+	})
+
+	t.Run("edge case: no generator", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		assert.Equal(t, "", chaos.OneOf[string]().Generate(c))
+	})
+
+	t.Run("elements", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		g := chaos.Elements(1, 2, 3)
+		for i := 0; i < 100; i++ {
+			assert.Contains(t, []int{1, 2, 3}, g.Generate(c))
+		}
+	})
+}
+
+func TestFrequency(t *testing.T) {
+	t.Run("respects weights", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		g := chaos.Frequency(
+			chaos.Weighted[string]{Weight: 3, Gen: chaos.Const("a")},
+			chaos.Weighted[string]{Weight: 1, Gen: chaos.Const("b")},
+			chaos.Weighted[string]{Weight: 0, Gen: chaos.Const("never")},
+		)
+		counts := make(map[string]int)
+		const iterations = 10000
+		for i := 0; i < iterations; i++ {
+			counts[g.Generate(c)]++
+		}
+		assert.InDelta(t, iterations*3/4, counts["a"], iterations*0.03)
+		assert.InDelta(t, iterations/4, counts["b"], iterations*0.03)
+		assert.Zero(t, counts["never"])
+	})
+
+	t.Run("edge case: no positive weight", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		g := chaos.Frequency(chaos.Weighted[int]{Weight: 0, Gen: chaos.Const(1)})
+		assert.Equal(t, 0, g.Generate(c))
+	})
+}
+
+func TestSliceOf(t *testing.T) {
+	c := chaos.New(t.Name())
+	g := chaos.SliceOf(chaos.StringGen(3), 2, 4)
+	for i := 0; i < 100; i++ {
+		s := g.Generate(c)
+		assert.GreaterOrEqual(t, len(s), 2)
+		assert.LessOrEqual(t, len(s), 4)
+	}
+}
+
+func TestStringOf(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		g := chaos.StringOf("abc", 0, 10)
+		assert.Equal(t, g.Generate(chaos.New(t.Name())), g.Generate(chaos.New(t.Name())))
+	})
+
+	t.Run("length and charset", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		g := chaos.StringOf("héllo", 2, 4)
+		lengths := make(map[int]bool)
+		for i := 0; i < 100; i++ {
+			s := g.Generate(c)
+			lengths[utf8.RuneCountInString(s)] = true
+			assert.Regexp(t, `^[héllo]{2,4}$`, s)
+		}
+		assert.Len(t, lengths, 3)
+	})
+
+	t.Run("edge case: empty charset", func(t *testing.T) {
+		assert.Equal(t, "", chaos.StringOf("", 2, 4).Generate(chaos.New(t.Name())))
+	})
+}
+
+func TestPtrOf(t *testing.T) {
+	c := chaos.New(t.Name())
+
+	p := chaos.PtrOf(chaos.Const(42)).Generate(c)
+	require.NotNil(t, p)
+	assert.Equal(t, 42, *p)
+
+	nils := 0
+	optional := chaos.Optional(chaos.Const(42))
+	for i := 0; i < 1000; i++ {
+		if v := optional.Generate(c); v == nil {
+			nils++
+		} else {
+			assert.Equal(t, 42, *v)
+		}
+	}
+	assert.InDelta(t, 500, nils, 100)
+}
+
+func TestStructOf(t *testing.T) {
+	type user struct {
+		Name  string
+		Email string
+		Age   int
+	}
+
+	g := chaos.StructOf(
+		chaos.Field(func(u *user, v string) { u.Name = v }, chaos.TitleGen(2, 2)),
+		chaos.Field(func(u *user, v string) { u.Email = v }, chaos.EmailGen()),
+		chaos.Field(func(u *user, v int) { u.Age = v }, chaos.IntGen(18, 99)),
+	)
+
+	t.Run("deterministic output", func(t *testing.T) {
+		assert.Equal(t, g.Generate(chaos.New(t.Name())), g.Generate(chaos.New(t.Name())))
+	})
+
+	t.Run("sets every field", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		u := g.Generate(c)
+		assert.Len(t, strings.Fields(u.Name), 2)
+		assert.Contains(t, u.Email, "@")
+		assert.GreaterOrEqual(t, u.Age, 18)
+		assert.LessOrEqual(t, u.Age, 99)
+	})
+
+	t.Run("zips values", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		triple := chaos.Zip3(chaos.Const(1), chaos.Const("a"), chaos.Const(true)).Generate(c)
+		assert.Equal(t, chaos.Triple[int, string, bool]{First: 1, Second: "a", Third: true}, triple)
+	})
+}
+
+func TestSized(t *testing.T) {
+	lists := chaos.Sized(func(size int) chaos.Gen[[]int] {
+		return chaos.SliceOf(chaos.IntGen(0, 9), 0, size)
+	})
+
+	t.Run("deterministic output", func(t *testing.T) {
+		assert.Equal(t, lists.Generate(chaos.New(t.Name())), lists.Generate(chaos.New(t.Name())))
+	})
+
+	t.Run("uses the size of the chaos", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		assert.Equal(t, 30, c.Size())
+		small := chaos.Resize(lists, 3)
+		for i := 0; i < 100; i++ {
+			assert.LessOrEqual(t, len(small.Generate(c)), 3)
+			assert.LessOrEqual(t, len(lists.Generate(c)), 30)
+		}
+		assert.Equal(t, 30, c.Size())
+	})
+}
+
+func TestRecursive(t *testing.T) {
+	type node struct {
+		Children []*node
+	}
+	trees := chaos.Recursive(chaos.Const[*node](nil), func(child chaos.Gen[*node]) chaos.Gen[*node] {
+		return chaos.PtrOf(chaos.StructOf(
+			chaos.Field(func(n *node, v []*node) { n.Children = v }, chaos.SliceOf(child, 0, 3)),
+		))
+	})
+	var depth func(n *node) int
+	depth = func(n *node) int {
+		if n == nil {
+			return 0
+		}
+		d := 0
+		for _, child := range n.Children {
+			d = max(d, depth(child))
+		}
+		return d + 1
+	}
+
+	t.Run("deterministic output", func(t *testing.T) {
+		assert.Equal(t, trees.Generate(chaos.New(t.Name())), trees.Generate(chaos.New(t.Name())))
+	})
+
+	t.Run("bounds the depth by the size", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		deepest := 0
+		for i := 0; i < 100; i++ {
+			d := depth(trees.Generate(c))
+			assert.LessOrEqual(t, d, 5)
+			deepest = max(deepest, d)
+			assert.LessOrEqual(t, depth(chaos.Resize(trees, 1).Generate(c)), 1)
+		}
+		assert.Greater(t, deepest, 2)
+	})
+}
+
+func TestFakerGen(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		g := chaos.FakerGen("sentence")
+		assert.Equal(t, g.Generate(chaos.New(t.Name())), g.Generate(chaos.New(t.Name())))
+	})
+
+	t.Run("fakers are the ones of Fill", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		assert.Contains(t, chaos.Fakers(), "email")
+		assert.True(t, slices.IsSorted(chaos.Fakers()))
+		for _, name := range chaos.Fakers() {
+			assert.NotEmpty(t, chaos.FakerGen(name).Generate(c), name)
+			field := reflect.StructField{Name: "A", Type: reflect.TypeFor[string](), Tag: reflect.StructTag(`chaos:"faker=` + name + `"`)}
+			v := reflect.New(reflect.StructOf([]reflect.StructField{field}))
+			require.NoError(t, c.Fill(v.Interface()), name)
+		}
+	})
+
+	t.Run("panics on unknown fakers", func(t *testing.T) {
+		assert.Panics(t, func() { chaos.FakerGen("unicorn") })
+	})
+}
@@ -1,15 +1,18 @@
 module github.com/raphoester/chaos
 
-go 1.22
+go 1.22.0
 
 require (
 	github.com/google/uuid v1.6.0
 	golang.org/x/exp v0.0.0-20240823005443-9b4947da3948
+	golang.org/x/tools v0.30.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/stretchr/testify v1.9.0
+	golang.org/x/mod v0.23.0 // indirect
+	golang.org/x/sync v0.11.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
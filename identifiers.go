@@ -0,0 +1,404 @@
+package chaos
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	base62Alphabet    = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// ksuidEpoch is the KSUID epoch (2014-05-13 16:53:20 UTC), in Unix seconds.
+	ksuidEpoch = 1400000000
+	// snowflakeEpoch is the Twitter Snowflake epoch (2010-11-04 01:42:54.657 UTC), in Unix milliseconds.
+	snowflakeEpoch = 1288834974657
+)
+
+var (
+	// idTimeMin and idTimeMax bound the timestamps of identifiers generated without an explicit time.
+	idTimeMin = time.Date(2015, time.January, 1, 0, 0, 0, 0, time.UTC)
+	idTimeMax = time.Date(2035, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// encodeBase encodes b as a big-endian number in the given alphabet, left-padded to length characters.
+func encodeBase(b []byte, alphabet string, length int) string {
+	n := new(big.Int).SetBytes(b)
+	base := big.NewInt(int64(len(alphabet)))
+	mod := new(big.Int)
+	out := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		n.DivMod(n, base, mod)
+		out[i] = alphabet[mod.Int64()]
+	}
+	return string(out)
+}
+
+// incrementBytes adds one to b, read as a big-endian number. It wraps around on overflow.
+func incrementBytes(b []byte) {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return
+		}
+	}
+}
+
+// idTime returns the timestamp used by identifiers generated without an explicit time.
+func (c *Chaos) idTime() time.Time {
+	return c.TimeBetween(idTimeMin, idTimeMax)
+}
+
+// UUIDv7 returns a random version 7 UUID embedding at.
+func UUIDv7(at time.Time) uuid.UUID {
+	return singleton.UUIDv7(at)
+}
+
+// UUIDv7 returns a deterministic version 7 UUID (RFC 9562) embedding at, with millisecond precision.
+// UUIDs generated for increasing times sort in the same order.
+func (c *Chaos) UUIDv7(at time.Time) uuid.UUID {
+	var id uuid.UUID
+	_, _ = c.rand().Read(id[6:])
+	setUUIDv7Time(&id, at)
+	return id
+}
+
+func setUUIDv7Time(id *uuid.UUID, at time.Time) {
+	var ms [8]byte
+	binary.BigEndian.PutUint64(ms[:], uint64(at.UnixMilli()))
+	copy(id[:6], ms[2:])
+	id[6] = id[6]&0x0f | 0x70
+	id[8] = id[8]&0x3f | 0x80
+}
+
+// incrementUUIDv7 adds one to the random part of a version 7 UUID, keeping its version and variant.
+func incrementUUIDv7(id *uuid.UUID) {
+	randB := binary.BigEndian.Uint64(id[8:])&(1<<62-1) + 1
+	if randB>>62 != 0 {
+		randA := binary.BigEndian.Uint16(id[6:])&0x0fff + 1
+		binary.BigEndian.PutUint16(id[6:], 0x7000|randA&0x0fff)
+	}
+	binary.BigEndian.PutUint64(id[8:], 0x8000000000000000|randB&(1<<62-1))
+}
+
+// ULID returns a random ULID.
+func ULID() string {
+	return singleton.ULID()
+}
+
+// ULID returns a deterministic ULID, in its canonical 26 characters Crockford base32 form.
+// Its timestamp is a deterministic time between 2015 and 2035.
+func (c *Chaos) ULID() string {
+	return c.ULIDAt(c.idTime())
+}
+
+// ULIDAt returns a random ULID embedding at.
+func ULIDAt(at time.Time) string {
+	return singleton.ULIDAt(at)
+}
+
+// ULIDAt returns a deterministic ULID embedding at, with millisecond precision.
+func (c *Chaos) ULIDAt(at time.Time) string {
+	var id [16]byte
+	_, _ = c.rand().Read(id[6:])
+	setULIDTime(&id, at)
+	return encodeBase(id[:], crockfordAlphabet, 26)
+}
+
+func setULIDTime(id *[16]byte, at time.Time) {
+	var ms [8]byte
+	binary.BigEndian.PutUint64(ms[:], uint64(at.UnixMilli()))
+	copy(id[:6], ms[2:])
+}
+
+// KSUID returns a random KSUID.
+func KSUID() string {
+	return singleton.KSUID()
+}
+
+// KSUID returns a deterministic KSUID, in its canonical 27 characters base62 form.
+// Its timestamp is a deterministic time between 2015 and 2035.
+func (c *Chaos) KSUID() string {
+	return c.KSUIDAt(c.idTime())
+}
+
+// KSUIDAt returns a random KSUID embedding at.
+func KSUIDAt(at time.Time) string {
+	return singleton.KSUIDAt(at)
+}
+
+// KSUIDAt returns a deterministic KSUID embedding at, with second precision.
+// at must be between 2014-05-13 and 2150-06-19 to be represented.
+func (c *Chaos) KSUIDAt(at time.Time) string {
+	var id [20]byte
+	_, _ = c.rand().Read(id[4:])
+	setKSUIDTime(&id, at)
+	return encodeBase(id[:], base62Alphabet, 27)
+}
+
+func setKSUIDTime(id *[20]byte, at time.Time) {
+	binary.BigEndian.PutUint32(id[:4], uint32(at.Unix()-ksuidEpoch))
+}
+
+// Snowflake returns a random Snowflake ID generated by nodeID.
+func Snowflake(nodeID int64) int64 {
+	return singleton.Snowflake(nodeID)
+}
+
+// Snowflake returns a deterministic Snowflake ID generated by nodeID.
+// Its timestamp is a deterministic time between 2015 and 2035.
+func (c *Chaos) Snowflake(nodeID int64) int64 {
+	return c.SnowflakeAt(nodeID, c.idTime())
+}
+
+// SnowflakeAt returns a random Snowflake ID generated by nodeID at the given time.
+func SnowflakeAt(nodeID int64, at time.Time) int64 {
+	return singleton.SnowflakeAt(nodeID, at)
+}
+
+// SnowflakeAt returns a deterministic Snowflake ID generated by nodeID at the given time.
+// It uses the Twitter layout: 41 bits of milliseconds since the Twitter epoch,
+// 10 bits of node ID (truncated) and a 12 bits sequence number.
+func (c *Chaos) SnowflakeAt(nodeID int64, at time.Time) int64 {
+	return snowflake(nodeID, at, c.Int64(1<<12-1))
+}
+
+func snowflake(nodeID int64, at time.Time, sequence int64) int64 {
+	ms := (at.UnixMilli() - snowflakeEpoch) & (1<<41 - 1)
+	return ms<<22 | (nodeID&(1<<10-1))<<12 | sequence&(1<<12-1)
+}
+
+// IDSequence is an infinite sequence of monotonically increasing identifiers.
+type IDSequence[T any] struct {
+	next func() T
+}
+
+// Next returns the next identifier of the sequence.
+func (s *IDSequence[T]) Next() T {
+	return s.next()
+}
+
+// idClock yields the timestamps of a monotonic sequence, and reports whether
+// each of them falls in the same time unit as the previous one.
+type idClock struct {
+	at        time.Time
+	step      time.Duration
+	unit      time.Duration
+	started   bool
+	lastTicks int64
+}
+
+func (clk *idClock) tick() (time.Time, bool) {
+	at := clk.at
+	clk.at = clk.at.Add(clk.step)
+	ticks := at.UnixNano() / int64(clk.unit)
+	same := clk.started && ticks <= clk.lastTicks
+	if same {
+		// never go back in time, even with a negative step
+		ticks = clk.lastTicks
+		at = time.Unix(0, ticks*int64(clk.unit))
+	}
+	clk.started = true
+	clk.lastTicks = ticks
+	return at, same
+}
+
+// advance moves the clock to the time unit following the last one returned, and returns it.
+func (clk *idClock) advance() time.Time {
+	clk.lastTicks++
+	return time.Unix(0, clk.lastTicks*int64(clk.unit))
+}
+
+// UUIDv7Sequence returns a random sequence of increasing version 7 UUIDs.
+func UUIDv7Sequence(start time.Time, step time.Duration) *IDSequence[uuid.UUID] {
+	return singleton.UUIDv7Sequence(start, step)
+}
+
+// UUIDv7Sequence returns a deterministic sequence of strictly increasing version 7 UUIDs.
+// The n-th UUID embeds start + n*step. When several UUIDs fall in the same millisecond,
+// their random part is incremented instead of being drawn again, as described by RFC 9562.
+func (c *Chaos) UUIDv7Sequence(start time.Time, step time.Duration) *IDSequence[uuid.UUID] {
+	clk := &idClock{at: start, step: step, unit: time.Millisecond}
+	var last uuid.UUID
+	return &IDSequence[uuid.UUID]{next: func() uuid.UUID {
+		at, same := clk.tick()
+		if same {
+			incrementUUIDv7(&last)
+		} else {
+			last = c.UUIDv7(at)
+		}
+		return last
+	}}
+}
+
+// ULIDSequence returns a random sequence of increasing ULIDs.
+func ULIDSequence(start time.Time, step time.Duration) *IDSequence[string] {
+	return singleton.ULIDSequence(start, step)
+}
+
+// ULIDSequence returns a deterministic sequence of strictly increasing ULIDs.
+// The n-th ULID embeds start + n*step. When several ULIDs fall in the same millisecond,
+// their random part is incremented, following the ULID monotonicity specification.
+func (c *Chaos) ULIDSequence(start time.Time, step time.Duration) *IDSequence[string] {
+	clk := &idClock{at: start, step: step, unit: time.Millisecond}
+	var last [16]byte
+	return &IDSequence[string]{next: func() string {
+		at, same := clk.tick()
+		if same {
+			incrementBytes(last[6:])
+		} else {
+			_, _ = c.rand().Read(last[6:])
+			setULIDTime(&last, at)
+		}
+		return encodeBase(last[:], crockfordAlphabet, 26)
+	}}
+}
+
+// KSUIDSequence returns a random sequence of increasing KSUIDs.
+func KSUIDSequence(start time.Time, step time.Duration) *IDSequence[string] {
+	return singleton.KSUIDSequence(start, step)
+}
+
+// KSUIDSequence returns a deterministic sequence of strictly increasing KSUIDs.
+// The n-th KSUID embeds start + n*step. When several KSUIDs fall in the same second,
+// their payload is incremented instead of being drawn again.
+func (c *Chaos) KSUIDSequence(start time.Time, step time.Duration) *IDSequence[string] {
+	clk := &idClock{at: start, step: step, unit: time.Second}
+	var last [20]byte
+	return &IDSequence[string]{next: func() string {
+		at, same := clk.tick()
+		if same {
+			incrementBytes(last[4:])
+		} else {
+			_, _ = c.rand().Read(last[4:])
+			setKSUIDTime(&last, at)
+		}
+		return encodeBase(last[:], base62Alphabet, 27)
+	}}
+}
+
+// SnowflakeSequence returns a random sequence of increasing Snowflake IDs.
+func SnowflakeSequence(nodeID int64, start time.Time, step time.Duration) *IDSequence[int64] {
+	return singleton.SnowflakeSequence(nodeID, start, step)
+}
+
+// SnowflakeSequence returns a deterministic sequence of strictly increasing Snowflake IDs generated by nodeID.
+// The n-th ID embeds start + n*step. The sequence number starts at a deterministic value and
+// is incremented when several IDs fall in the same millisecond; it is reset to 0 otherwise.
+// When the sequence numbers of a millisecond are exhausted, the next millisecond is used.
+func (c *Chaos) SnowflakeSequence(nodeID int64, start time.Time, step time.Duration) *IDSequence[int64] {
+	clk := &idClock{at: start, step: step, unit: time.Millisecond}
+	sequence := c.Int64(1<<12 - 1)
+	first := true
+	return &IDSequence[int64]{next: func() int64 {
+		at, same := clk.tick()
+		switch {
+		case same:
+			sequence++
+		case !first:
+			sequence = 0
+		}
+		first = false
+		if sequence > 1<<12-1 {
+			// the sequence numbers of this millisecond are exhausted: borrow the next one
+			at = clk.advance()
+			sequence = 0
+		}
+		return snowflake(nodeID, at, sequence)
+	}}
+}
+
+const defaultNanoIDAlphabet = "_-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// NanoID returns a random NanoID.
+func NanoID(size int, alphabet string) string {
+	return singleton.NanoID(size, alphabet)
+}
+
+// NanoID returns a deterministic NanoID of size characters picked from alphabet.
+// Behavior:
+//   - If size <= 0, the default NanoID size of 21 is used.
+//   - If alphabet is empty, the default URL-safe NanoID alphabet is used.
+func (c *Chaos) NanoID(size int, alphabet string) string {
+	if size <= 0 {
+		size = 21
+	}
+	if alphabet == "" {
+		alphabet = defaultNanoIDAlphabet
+	}
+	chars := []rune(alphabet)
+	r := c.rand()
+	ret := make([]rune, size)
+	for i := range ret {
+		ret[i] = chars[r.Intn(len(chars))]
+	}
+	return string(ret)
+}
+
+// ObjectID returns a random MongoDB ObjectID.
+func ObjectID() string {
+	return singleton.ObjectID()
+}
+
+// ObjectID returns a deterministic MongoDB ObjectID, in its 24 characters hexadecimal form.
+// Its timestamp is a deterministic time between 2015 and 2035.
+func (c *Chaos) ObjectID() string {
+	return c.ObjectIDAt(c.idTime())
+}
+
+// ObjectIDAt returns a random MongoDB ObjectID embedding at.
+func ObjectIDAt(at time.Time) string {
+	return singleton.ObjectIDAt(at)
+}
+
+// ObjectIDAt returns a deterministic 12 bytes MongoDB ObjectID embedding at, with second precision.
+func (c *Chaos) ObjectIDAt(at time.Time) string {
+	var id [12]byte
+	binary.BigEndian.PutUint32(id[:4], uint32(at.Unix()))
+	_, _ = c.rand().Read(id[4:])
+	return hex.EncodeToString(id[:])
+}
+
+// namespace returns the UUID namespace of the chaos, derived from its seed.
+func (c *Chaos) namespace() uuid.UUID {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(c.seed))
+}
+
+// UUIDv5 returns the name-based version 5 UUID of key in the default chaos namespace.
+func UUIDv5(key string) uuid.UUID {
+	return singleton.UUIDv5(key)
+}
+
+// UUIDv5 returns the name-based version 5 (SHA-1) UUID of key, in a namespace derived from the seed.
+// The same seed and key always give the same UUID, regardless of the previous calls,
+// so a logical entity keeps its ID across tests. It does not consume any value from the chaos.
+func (c *Chaos) UUIDv5(key string) uuid.UUID {
+	return uuid.NewSHA1(c.namespace(), []byte(key))
+}
+
+// UUIDv3 returns the name-based version 3 UUID of key in the default chaos namespace.
+func UUIDv3(key string) uuid.UUID {
+	return singleton.UUIDv3(key)
+}
+
+// UUIDv3 returns the name-based version 3 (MD5) UUID of key, in a namespace derived from the seed.
+// The same seed and key always give the same UUID, regardless of the previous calls,
+// so a logical entity keeps its ID across tests. It does not consume any value from the chaos.
+func (c *Chaos) UUIDv3(key string) uuid.UUID {
+	return uuid.NewMD5(c.namespace(), []byte(key))
+}
+
+// ShortCode returns a random human-friendly code.
+func ShortCode(length int) string {
+	return singleton.ShortCode(length)
+}
+
+// ShortCode returns a deterministic human-friendly code of length characters.
+// It uses the Crockford base32 alphabet, which excludes the ambiguous letters I, L, O and U.
+func (c *Chaos) ShortCode(length int) string {
+	return c.StringFrom(crockfordAlphabet, length)
+}
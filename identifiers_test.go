@@ -0,0 +1,255 @@
+package chaos_test
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/raphoester/chaos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUUIDv7(t *testing.T) {
+	at := time.Date(2024, time.June, 1, 12, 30, 0, 123_000_000, time.UTC)
+
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.UUIDv7(at), c.UUIDv7(at))
+	})
+
+	t.Run("generates valid UUIDs embedding the time", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 1000; i++ {
+			id := c.UUIDv7(at)
+			assert.Equal(t, uuid.Version(7), id.Version())
+			assert.Equal(t, uuid.RFC4122, id.Variant())
+			sec, nsec := id.Time().UnixTime()
+			assert.Equal(t, at, time.Unix(sec, nsec).UTC())
+		}
+	})
+
+	t.Run("sorts by time", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		earlier := c.UUIDv7(at)
+		later := c.UUIDv7(at.Add(time.Millisecond))
+		assert.Less(t, earlier.String(), later.String())
+	})
+}
+
+func TestULID(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.ULID(), c.ULID())
+	})
+
+	t.Run("different seeds produce unique results", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		results := make(map[string]bool)
+		for i := 0; i < 1000; i++ {
+			result := c.ULID()
+			assert.Regexp(t, `^[0-7][0-9A-HJKMNP-TV-Z]{25}$`, result)
+			assert.False(t, results[result], "Expected unique result for each seed")
+			results[result] = true
+		}
+	})
+
+	t.Run("sorts by time", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		at := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+		assert.Less(t, c.ULIDAt(at), c.ULIDAt(at.Add(time.Millisecond)))
+	})
+}
+
+func TestKSUID(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.KSUID(), c.KSUID())
+	})
+
+	t.Run("respects format", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 1000; i++ {
+			assert.Regexp(t, `^[0-9A-Za-z]{27}$`, c.KSUID())
+		}
+	})
+
+	t.Run("sorts by time", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		at := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+		assert.Less(t, c.KSUIDAt(at), c.KSUIDAt(at.Add(time.Second)))
+	})
+}
+
+func TestSnowflake(t *testing.T) {
+	at := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.Snowflake(42), c.Snowflake(42))
+	})
+
+	t.Run("embeds time and node", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		id := c.SnowflakeAt(42, at)
+		assert.Equal(t, at.UnixMilli()-1288834974657, id>>22)
+		assert.Equal(t, int64(42), id>>12&(1<<10-1))
+	})
+
+	t.Run("is positive", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 1000; i++ {
+			assert.Positive(t, c.Snowflake(int64(i)))
+		}
+	})
+}
+
+func TestIDSequences(t *testing.T) {
+	start := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	steps := []time.Duration{0, time.Microsecond, time.Millisecond, time.Second}
+
+	t.Run("deterministic output", func(t *testing.T) {
+		seq1 := chaos.New(t.Name()).ULIDSequence(start, time.Millisecond)
+		seq2 := chaos.New(t.Name()).ULIDSequence(start, time.Millisecond)
+		for i := 0; i < 100; i++ {
+			assert.Equal(t, seq1.Next(), seq2.Next())
+		}
+	})
+
+	t.Run("UUIDv7 sequences strictly increase", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for _, step := range steps {
+			seq := c.UUIDv7Sequence(start, step)
+			previous := seq.Next()
+			for i := 0; i < 1000; i++ {
+				next := seq.Next()
+				assert.Equal(t, uuid.Version(7), next.Version())
+				require.Less(t, previous.String(), next.String(), "step %s", step)
+				previous = next
+			}
+		}
+	})
+
+	t.Run("ULID sequences strictly increase", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for _, step := range steps {
+			ids := make([]string, 1000)
+			seq := c.ULIDSequence(start, step)
+			for i := range ids {
+				ids[i] = seq.Next()
+			}
+			assert.True(t, sort.StringsAreSorted(ids), "step %s", step)
+			assert.Len(t, uniqueStrings(ids), len(ids))
+		}
+	})
+
+	t.Run("KSUID sequences strictly increase", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for _, step := range steps {
+			ids := make([]string, 1000)
+			seq := c.KSUIDSequence(start, step)
+			for i := range ids {
+				ids[i] = seq.Next()
+			}
+			assert.True(t, sort.StringsAreSorted(ids), "step %s", step)
+			assert.Len(t, uniqueStrings(ids), len(ids))
+		}
+	})
+
+	t.Run("Snowflake sequences strictly increase", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for _, step := range steps {
+			seq := c.SnowflakeSequence(7, start, step)
+			previous := seq.Next()
+			for i := 0; i < 1000; i++ {
+				next := seq.Next()
+				require.Greater(t, next, previous, "step %s", step)
+				previous = next
+			}
+		}
+	})
+}
+
+func uniqueStrings(slice []string) map[string]struct{} {
+	unique := make(map[string]struct{})
+	for _, s := range slice {
+		unique[s] = struct{}{}
+	}
+	return unique
+}
+
+func TestNanoID(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.NanoID(0, ""), c.NanoID(0, ""))
+	})
+
+	t.Run("uses defaults", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		assert.Regexp(t, `^[A-Za-z0-9_-]{21}$`, c.NanoID(0, ""))
+	})
+
+	t.Run("respects size and alphabet", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		assert.Regexp(t, `^[abc]{10}$`, c.NanoID(10, "abc"))
+	})
+}
+
+func TestObjectID(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.ObjectID(), c.ObjectID())
+	})
+
+	t.Run("embeds the time", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		at := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+		id := c.ObjectIDAt(at)
+		assert.Regexp(t, `^[0-9a-f]{24}$`, id)
+		assert.Equal(t, "665a6480", id[:8])
+	})
+}
+
+func TestNameBasedUUIDs(t *testing.T) {
+	t.Run("same seed and key produce the same UUID", func(t *testing.T) {
+		c1 := chaos.New(t.Name())
+		c2 := chaos.New(t.Name())
+		c2.Int(10)
+		assert.Equal(t, c1.UUIDv5("user-1"), c2.UUIDv5("user-1"))
+		assert.Equal(t, c1.UUIDv3("user-1"), c2.UUIDv3("user-1"))
+	})
+
+	t.Run("different keys or seeds produce different UUIDs", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		assert.NotEqual(t, c.UUIDv5("user-1"), c.UUIDv5("user-2"))
+		assert.NotEqual(t, c.UUIDv5("user-1"), chaos.New("other").UUIDv5("user-1"))
+	})
+
+	t.Run("generates valid UUIDs", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		assert.Equal(t, uuid.Version(5), c.UUIDv5("key").Version())
+		assert.Equal(t, uuid.Version(3), c.UUIDv3("key").Version())
+		assert.Equal(t, uuid.RFC4122, c.UUIDv5("key").Variant())
+	})
+}
+
+func TestShortCode(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.ShortCode(8), c.ShortCode(8))
+	})
+
+	t.Run("does not use ambiguous characters", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		assert.Regexp(t, `^[0-9A-HJKMNP-TV-Z]{1000}$`, c.ShortCode(1000))
+	})
+}
@@ -0,0 +1,164 @@
+package tags
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	// StringLength is the length of generated strings.
+	StringLength = 10
+	// MinLen and MaxLen bound the number of items of generated slices and maps.
+	MinLen = 1
+	MaxLen = 5
+	// MaxDuration bounds generated durations.
+	MaxDuration = 24 * time.Hour
+	// MaxFloat bounds the absolute value of generated floats.
+	MaxFloat = 1e6
+)
+
+var (
+	// MinTime and MaxTime bound generated times.
+	MinTime = time.Unix(0, 0)
+	MaxTime = time.Unix(1<<32, 0)
+)
+
+// ParseInt parses an integer of min, max or oneof, of the given bit size.
+// Like Go literals, it accepts base prefixes and underscores, as in 0x10 or 1_000.
+func ParseInt(raw string, bitSize int) (int64, error) {
+	return strconv.ParseInt(raw, 0, bitSize)
+}
+
+// ParseUint parses an unsigned integer of min, max or oneof, of the given bit size.
+// Like Go literals, it accepts base prefixes and underscores, as in 0x10 or 1_000.
+func ParseUint(raw string, bitSize int) (uint64, error) {
+	return strconv.ParseUint(raw, 0, bitSize)
+}
+
+// ParseFloat parses a float of min, max or oneof, of the given bit size.
+func ParseFloat(raw string, bitSize int) (float64, error) {
+	return strconv.ParseFloat(raw, bitSize)
+}
+
+// ParseDuration parses a duration of min, max or oneof, with the syntax of time.ParseDuration.
+func ParseDuration(raw string) (time.Duration, error) {
+	return time.ParseDuration(raw)
+}
+
+// ParseTime parses a time of min, max or oneof, in the RFC 3339 format.
+func ParseTime(raw string) (time.Time, error) {
+	return time.Parse(time.RFC3339, raw)
+}
+
+// IntRange returns the smallest and largest signed integers of the given bit size.
+func IntRange(bitSize int) (int64, int64) {
+	return -1 << (bitSize - 1), 1<<(bitSize-1) - 1
+}
+
+// UintMax returns the largest unsigned integer of the given bit size.
+func UintMax(bitSize int) uint64 {
+	return math.MaxUint64 >> (64 - bitSize)
+}
+
+// IntBounds returns the bounds of integers of the given bit size, following the min and max
+// options. The default range is the whole range of the type.
+func (o *Options) IntBounds(bitSize int) (int64, int64, error) {
+	lo, hi := IntRange(bitSize)
+	if err := parseBounds(o, &lo, &hi, func(raw string) (int64, error) { return ParseInt(raw, bitSize) }); err != nil {
+		return 0, 0, err
+	}
+	if lo > hi {
+		return 0, 0, o.reversed()
+	}
+	return lo, hi, nil
+}
+
+// UintBounds returns the bounds of unsigned integers of the given bit size, like IntBounds.
+func (o *Options) UintBounds(bitSize int) (uint64, uint64, error) {
+	lo, hi := uint64(0), UintMax(bitSize)
+	if err := parseBounds(o, &lo, &hi, func(raw string) (uint64, error) { return ParseUint(raw, bitSize) }); err != nil {
+		return 0, 0, err
+	}
+	if lo > hi {
+		return 0, 0, o.reversed()
+	}
+	return lo, hi, nil
+}
+
+// FloatBounds returns the bounds of floats of the given bit size, following the min and max options.
+// When a single bound is set beyond the default range, the other one is moved to keep the
+// default width: min=2e6 gives values up to 4e6.
+func (o *Options) FloatBounds(bitSize int) (float64, float64, error) {
+	lo, hi := -MaxFloat, MaxFloat
+	if err := parseBounds(o, &lo, &hi, func(raw string) (float64, error) { return ParseFloat(raw, bitSize) }); err != nil {
+		return 0, 0, err
+	}
+	switch {
+	case o.Max == nil && lo >= hi:
+		hi = lo + 2*MaxFloat
+	case o.Min == nil && hi <= lo:
+		lo = hi - 2*MaxFloat
+	case lo > hi:
+		return 0, 0, o.reversed()
+	}
+	return lo, hi, nil
+}
+
+// DurationBounds returns the bounds of durations following the min and max options, like FloatBounds.
+func (o *Options) DurationBounds() (time.Duration, time.Duration, error) {
+	lo, hi := time.Duration(0), MaxDuration
+	if err := parseBounds(o, &lo, &hi, ParseDuration); err != nil {
+		return 0, 0, err
+	}
+	switch {
+	case o.Max == nil && lo >= hi:
+		hi = lo + MaxDuration
+	case o.Min == nil && hi <= lo:
+		lo = hi - MaxDuration
+	case lo > hi:
+		return 0, 0, o.reversed()
+	}
+	return lo, hi, nil
+}
+
+// TimeBounds returns the bounds of times following the min and max options, like FloatBounds.
+func (o *Options) TimeBounds() (time.Time, time.Time, error) {
+	lo, hi := MinTime, MaxTime
+	width := MaxTime.Sub(MinTime)
+	if err := parseBounds(o, &lo, &hi, ParseTime); err != nil {
+		return lo, hi, err
+	}
+	switch {
+	case o.Max == nil && !lo.Before(hi):
+		hi = lo.Add(width)
+	case o.Min == nil && !hi.After(lo):
+		lo = hi.Add(-width)
+	case lo.After(hi):
+		return lo, hi, fmt.Errorf("min %s is after max %s", *o.Min, *o.Max)
+	}
+	return lo, hi, nil
+}
+
+// parseBounds sets lo and hi to the min and max options parsed by parse, when they are set.
+func parseBounds[T any](o *Options, lo, hi *T, parse func(raw string) (T, error)) error {
+	for _, bound := range []struct {
+		raw *string
+		dst *T
+	}{{o.Min, lo}, {o.Max, hi}} {
+		if bound.raw == nil {
+			continue
+		}
+		v, err := parse(*bound.raw)
+		if err != nil {
+			return err
+		}
+		*bound.dst = v
+	}
+	return nil
+}
+
+func (o *Options) reversed() error {
+	return fmt.Errorf("min %s is greater than max %s", *o.Min, *o.Max)
+}
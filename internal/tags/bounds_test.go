@@ -0,0 +1,81 @@
+package tags_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/raphoester/chaos/internal/tags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bounds(t *testing.T, tag string) *tags.Options {
+	t.Helper()
+	o, err := tags.Parse(tag, isFaker)
+	require.NoError(t, err)
+	return o
+}
+
+func TestBounds(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		lo1, hi1, err1 := bounds(t, "min=-5").IntBounds(8)
+		lo2, hi2, err2 := bounds(t, "min=-5").IntBounds(8)
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.Equal(t, [2]int64{lo1, hi1}, [2]int64{lo2, hi2})
+	})
+
+	t.Run("integers default to the range of the type", func(t *testing.T) {
+		lo, hi, err := bounds(t, "").IntBounds(8)
+		require.NoError(t, err)
+		assert.Equal(t, [2]int64{math.MinInt8, math.MaxInt8}, [2]int64{lo, hi})
+
+		lo, hi, err = bounds(t, "min=-5").IntBounds(64)
+		require.NoError(t, err)
+		assert.Equal(t, [2]int64{-5, math.MaxInt64}, [2]int64{lo, hi})
+
+		ulo, uhi, err := bounds(t, "max=0x10").UintBounds(16)
+		require.NoError(t, err)
+		assert.Equal(t, [2]uint64{0, 16}, [2]uint64{ulo, uhi})
+	})
+
+	t.Run("a single bound keeps the default width", func(t *testing.T) {
+		lo, hi, err := bounds(t, "min=2e6").FloatBounds(64)
+		require.NoError(t, err)
+		assert.Equal(t, [2]float64{2e6, 4e6}, [2]float64{lo, hi})
+
+		dlo, dhi, err := bounds(t, "min=48h").DurationBounds()
+		require.NoError(t, err)
+		assert.Equal(t, [2]time.Duration{48 * time.Hour, 72 * time.Hour}, [2]time.Duration{dlo, dhi})
+
+		tlo, thi, err := bounds(t, "max=1960-01-01T00:00:00Z").TimeBounds()
+		require.NoError(t, err)
+		assert.Equal(t, tags.MaxTime.Sub(tags.MinTime), thi.Sub(tlo))
+	})
+
+	t.Run("returns error for invalid bounds", func(t *testing.T) {
+		_, _, err := bounds(t, "min=3,max=2").IntBounds(64)
+		assert.ErrorContains(t, err, "min 3 is greater than max 2")
+		_, _, err = bounds(t, "max=256").UintBounds(8)
+		assert.Error(t, err)
+		_, _, err = bounds(t, "min=1h,max=1m").DurationBounds()
+		assert.Error(t, err)
+		_, _, err = bounds(t, "min=2001-01-01T00:00:00Z,max=2000-01-01T00:00:00Z").TimeBounds()
+		assert.ErrorContains(t, err, "is after max")
+	})
+}
+
+func TestLengthRange(t *testing.T) {
+	var none *tags.Options
+	lo, hi := none.LengthRange(1, 5)
+	assert.Equal(t, [2]int{1, 5}, [2]int{lo, hi})
+
+	lo, hi = bounds(t, "minlen=7").LengthRange(1, 5)
+	assert.Equal(t, [2]int{7, 7}, [2]int{lo, hi})
+
+	elem := bounds(t, "len=3,nil=0.5,keep").Element()
+	assert.False(t, elem.HasLength())
+	assert.Nil(t, elem.NilRatio)
+	assert.False(t, elem.Keep)
+}
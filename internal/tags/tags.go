@@ -0,0 +1,184 @@
+// Package tags parses the chaos struct tags, read by chaos.Fill and by the builders
+// generated by cmd/chaosgen, so both follow the same grammar.
+package tags
+
+import (
+	"errors"
+	"fmt"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+)
+
+// Name is the name of the struct tag.
+const Name = "chaos"
+
+// Options are the options of a chaos struct tag. They have the meaning documented by chaos.Fill.
+type Options struct {
+	Skip, Keep, Unique     bool
+	NilRatio               *float64
+	Length, MinLen, MaxLen *int
+	// Min and Max are parsed by the users of the options, according to the type of the field.
+	Min, Max              *string
+	Regex, Charset, Faker *string
+	Oneof                 []string
+}
+
+// Parse parses a chaos struct tag. isFaker reports whether a faker name is known.
+// Options are separated by commas. As regular expressions may contain commas,
+// the regex option must be the last one: it consumes the rest of the tag.
+func Parse(tag string, isFaker func(name string) bool) (*Options, error) {
+	opts := &Options{}
+	seen := make(map[string]bool)
+	for rest := tag; rest != ""; {
+		option := rest
+		if !strings.HasPrefix(strings.TrimLeft(rest, " "), "regex=") {
+			option, rest, _ = strings.Cut(rest, ",")
+		} else {
+			rest = ""
+		}
+		key, value, hasValue := strings.Cut(option, "=")
+		key = strings.TrimSpace(key)
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate option %q", key)
+		}
+		seen[key] = true
+
+		switch key {
+		case "-", "skip", "keep", "unique":
+			if hasValue {
+				return nil, fmt.Errorf("option %q does not take a value", key)
+			}
+			opts.Skip = opts.Skip || key == "-" || key == "skip"
+			opts.Keep = opts.Keep || key == "keep"
+			opts.Unique = opts.Unique || key == "unique"
+			continue
+		case "min", "max", "len", "minlen", "maxlen", "regex", "oneof", "charset", "faker", "nil":
+			if !hasValue || value == "" {
+				return nil, fmt.Errorf("option %q requires a value", key)
+			}
+		default:
+			return nil, fmt.Errorf("unknown option %q", key)
+		}
+
+		var err error
+		switch key {
+		case "min":
+			opts.Min = &value
+		case "max":
+			opts.Max = &value
+		case "len":
+			opts.Length, err = parseLength(value)
+		case "minlen":
+			opts.MinLen, err = parseLength(value)
+		case "maxlen":
+			opts.MaxLen, err = parseLength(value)
+		case "regex":
+			if _, err = syntax.Parse(value, syntax.Perl); err == nil {
+				opts.Regex = &value
+			}
+		case "oneof":
+			opts.Oneof = strings.Fields(value)
+		case "charset":
+			opts.Charset = &value
+		case "faker":
+			if !isFaker(value) {
+				err = fmt.Errorf("unknown faker %q", value)
+			}
+			opts.Faker = &value
+		case "nil":
+			var ratio float64
+			ratio, err = strconv.ParseFloat(value, 64)
+			if err == nil && (ratio < 0 || ratio > 1) {
+				err = fmt.Errorf("%v is not between 0 and 1", ratio)
+			}
+			opts.NilRatio = &ratio
+		}
+		if err != nil {
+			return nil, fmt.Errorf("option %q: %v", key, err)
+		}
+	}
+
+	if opts.Length != nil && (opts.MinLen != nil || opts.MaxLen != nil) {
+		return nil, errors.New("len cannot be combined with minlen or maxlen")
+	}
+	if opts.MinLen != nil && opts.MaxLen != nil && *opts.MinLen > *opts.MaxLen {
+		return nil, fmt.Errorf("minlen %d is greater than maxlen %d", *opts.MinLen, *opts.MaxLen)
+	}
+	sources := 0
+	for _, set := range []bool{opts.Regex != nil, opts.Oneof != nil, opts.Charset != nil, opts.Faker != nil} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return nil, errors.New("regex, oneof, charset and faker cannot be combined")
+	}
+	if opts.Oneof != nil && (opts.Min != nil || opts.Max != nil) {
+		return nil, errors.New("oneof cannot be combined with min or max")
+	}
+	return opts, nil
+}
+
+func parseLength(value string) (*int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("%d is negative", n)
+	}
+	return &n, nil
+}
+
+// SetsValue reports whether the options set the innermost values, rather than only the
+// container or the flags of the field.
+func (o *Options) SetsValue() bool {
+	return o != nil && (o.Min != nil || o.Max != nil || o.Regex != nil || o.Charset != nil || o.Faker != nil || len(o.Oneof) > 0)
+}
+
+// HasLength reports whether the options set a length.
+func (o *Options) HasLength() bool {
+	return o != nil && (o.Length != nil || o.MinLen != nil || o.MaxLen != nil)
+}
+
+// Pointee returns the options of the value held by a pointer.
+func (o *Options) Pointee() *Options {
+	if o == nil {
+		return nil
+	}
+	elem := *o
+	elem.NilRatio = nil
+	elem.Keep = false
+	return &elem
+}
+
+// Element returns the options of the items of a slice, array or map,
+// without the options that only apply to the container.
+func (o *Options) Element() *Options {
+	if o == nil {
+		return nil
+	}
+	elem := *o.Pointee()
+	elem.Length, elem.MinLen, elem.MaxLen = nil, nil, nil
+	return &elem
+}
+
+// LengthRange returns the length bounds set by the options, defaulting to [lo, hi].
+func (o *Options) LengthRange(lo, hi int) (int, int) {
+	if o == nil {
+		return lo, hi
+	}
+	if o.Length != nil {
+		return *o.Length, *o.Length
+	}
+	if o.MinLen != nil {
+		lo = *o.MinLen
+		hi = max(hi, lo)
+	}
+	if o.MaxLen != nil {
+		hi = *o.MaxLen
+		lo = min(lo, hi)
+	}
+	return lo, hi
+}
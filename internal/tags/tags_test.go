@@ -0,0 +1,75 @@
+package tags_test
+
+import (
+	"testing"
+
+	"github.com/raphoester/chaos/internal/tags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func isFaker(name string) bool { return name == "email" }
+
+func TestParse(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		o1, err1 := tags.Parse("unique,len=3,charset=abc", isFaker)
+		o2, err2 := tags.Parse("unique,len=3,charset=abc", isFaker)
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.Equal(t, o1, o2)
+	})
+
+	t.Run("parses options", func(t *testing.T) {
+		o, err := tags.Parse("keep, unique,nil=0.5,minlen=1,maxlen=3,faker=email", isFaker)
+		require.NoError(t, err)
+		assert.True(t, o.Keep)
+		assert.True(t, o.Unique)
+		assert.False(t, o.Skip)
+		assert.Equal(t, 0.5, *o.NilRatio)
+		assert.Equal(t, 1, *o.MinLen)
+		assert.Equal(t, 3, *o.MaxLen)
+		assert.Equal(t, "email", *o.Faker)
+	})
+
+	t.Run("regex consumes the rest of the tag", func(t *testing.T) {
+		o, err := tags.Parse("len=2, regex=^[a-z]{1,3}$", isFaker)
+		require.NoError(t, err)
+		assert.Equal(t, "^[a-z]{1,3}$", *o.Regex)
+	})
+
+	t.Run("returns error for invalid tags", func(t *testing.T) {
+		for name, tag := range map[string]string{
+			"unknown option":   "size=3",
+			"duplicate option": "min=1,min=2",
+			"missing value":    "len=",
+			"unexpected value": "keep=1",
+			"negative length":  "len=-1",
+			"minlen over max":  "minlen=3,maxlen=2",
+			"len and minlen":   "len=3,minlen=2",
+			"unknown faker":    "faker=phone",
+			"invalid regex":    "regex=(",
+			"nil out of range": "nil=2",
+			"two sources":      "charset=ab,oneof=a b",
+			"oneof and min":    "oneof=1 2,min=0",
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := tags.Parse(tag, isFaker)
+				assert.Error(t, err)
+			})
+		}
+	})
+}
+
+func TestParseInt(t *testing.T) {
+	for raw, want := range map[string]int64{"16": 16, "0x10": 16, "-0b11": -3, "1_000": 1000} {
+		got, err := tags.ParseInt(raw, 64)
+		require.NoError(t, err, raw)
+		assert.Equal(t, want, got, raw)
+	}
+	_, err := tags.ParseInt("128", 8)
+	assert.Error(t, err)
+
+	got, err := tags.ParseUint("0xff", 8)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(255), got)
+}
@@ -0,0 +1,321 @@
+package chaos
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+// IPScope restricts the range in which IP addresses are generated.
+type IPScope int
+
+const (
+	// AnyIPScope allows any address of the family.
+	AnyIPScope IPScope = iota
+	// PrivateIPScope restricts addresses to private ranges (RFC 1918, RFC 4193).
+	PrivateIPScope
+	// PublicIPScope restricts addresses to globally routable unicast ranges.
+	PublicIPScope
+	// LoopbackIPScope restricts addresses to loopback ranges.
+	LoopbackIPScope
+	// DocumentationIPScope restricts addresses to documentation ranges (RFC 5737, RFC 3849).
+	DocumentationIPScope
+)
+
+var (
+	privateIPv4Prefixes = []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("172.16.0.0/12"),
+		netip.MustParsePrefix("192.168.0.0/16"),
+	}
+	loopbackIPv4Prefixes      = []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")}
+	documentationIPv4Prefixes = []netip.Prefix{
+		netip.MustParsePrefix("192.0.2.0/24"),
+		netip.MustParsePrefix("198.51.100.0/24"),
+		netip.MustParsePrefix("203.0.113.0/24"),
+	}
+	// nonPublicIPv4Prefixes lists the special purpose ranges (RFC 6890) excluded from public addresses.
+	nonPublicIPv4Prefixes = append(append(append([]netip.Prefix{
+		netip.MustParsePrefix("0.0.0.0/8"),
+		netip.MustParsePrefix("100.64.0.0/10"),
+		netip.MustParsePrefix("169.254.0.0/16"),
+		netip.MustParsePrefix("192.0.0.0/24"),
+		netip.MustParsePrefix("198.18.0.0/15"),
+		netip.MustParsePrefix("224.0.0.0/4"),
+		netip.MustParsePrefix("240.0.0.0/4"),
+	}, privateIPv4Prefixes...), loopbackIPv4Prefixes...), documentationIPv4Prefixes...)
+
+	privateIPv6Prefixes       = []netip.Prefix{netip.MustParsePrefix("fc00::/7")}
+	loopbackIPv6Prefixes      = []netip.Prefix{netip.MustParsePrefix("::1/128")}
+	documentationIPv6Prefixes = []netip.Prefix{netip.MustParsePrefix("2001:db8::/32")}
+	publicIPv6Prefixes        = []netip.Prefix{netip.MustParsePrefix("2000::/3")}
+	// nonPublicIPv6Prefixes lists the special purpose ranges (RFC 6890) within 2000::/3 excluded
+	// from public addresses: IETF protocol assignments, 6to4, and documentation (RFC 3849, RFC 9637).
+	nonPublicIPv6Prefixes = append([]netip.Prefix{
+		netip.MustParsePrefix("2001::/23"),
+		netip.MustParsePrefix("2002::/16"),
+		netip.MustParsePrefix("3fff::/20"),
+	}, documentationIPv6Prefixes...)
+)
+
+// maxAddrAttempts bounds the number of draws used to find a public address.
+// The public ranges cover most of the address space, so this limit is never reached in practice.
+const maxAddrAttempts = 100
+
+// IPv4 returns a random IPv4 address.
+func IPv4() netip.Addr {
+	return singleton.IPv4()
+}
+
+// IPv4 returns a deterministic IPv4 address.
+func (c *Chaos) IPv4() netip.Addr {
+	return c.IPv4In(AnyIPScope)
+}
+
+// IPv4In returns a random IPv4 address within scope.
+func IPv4In(scope IPScope) netip.Addr {
+	return singleton.IPv4In(scope)
+}
+
+// IPv4In returns a deterministic IPv4 address within scope.
+func (c *Chaos) IPv4In(scope IPScope) netip.Addr {
+	switch scope {
+	case PrivateIPScope:
+		return c.IPInPrefix(NewSliceProcessor[[]netip.Prefix](c).Item(privateIPv4Prefixes))
+	case LoopbackIPScope:
+		return c.IPInPrefix(NewSliceProcessor[[]netip.Prefix](c).Item(loopbackIPv4Prefixes))
+	case DocumentationIPScope:
+		return c.IPInPrefix(NewSliceProcessor[[]netip.Prefix](c).Item(documentationIPv4Prefixes))
+	case PublicIPScope:
+		return c.publicAddr(netip.MustParsePrefix("0.0.0.0/0"), nonPublicIPv4Prefixes)
+	default:
+		return c.IPInPrefix(netip.MustParsePrefix("0.0.0.0/0"))
+	}
+}
+
+// IPv6 returns a random IPv6 address.
+func IPv6() netip.Addr {
+	return singleton.IPv6()
+}
+
+// IPv6 returns a deterministic IPv6 address.
+func (c *Chaos) IPv6() netip.Addr {
+	return c.IPv6In(AnyIPScope)
+}
+
+// IPv6In returns a random IPv6 address within scope.
+func IPv6In(scope IPScope) netip.Addr {
+	return singleton.IPv6In(scope)
+}
+
+// IPv6In returns a deterministic IPv6 address within scope.
+func (c *Chaos) IPv6In(scope IPScope) netip.Addr {
+	switch scope {
+	case PrivateIPScope:
+		return c.IPInPrefix(privateIPv6Prefixes[0])
+	case LoopbackIPScope:
+		return loopbackIPv6Prefixes[0].Addr()
+	case DocumentationIPScope:
+		return c.IPInPrefix(documentationIPv6Prefixes[0])
+	case PublicIPScope:
+		return c.publicAddr(publicIPv6Prefixes[0], nonPublicIPv6Prefixes)
+	default:
+		return c.IPInPrefix(netip.MustParsePrefix("::/0"))
+	}
+}
+
+func (c *Chaos) publicAddr(within netip.Prefix, excluded []netip.Prefix) netip.Addr {
+	var addr netip.Addr
+	for i := 0; i < maxAddrAttempts; i++ {
+		addr = c.IPInPrefix(within)
+		if !prefixesContain(excluded, addr) {
+			return addr
+		}
+	}
+	return addr
+}
+
+func prefixesContain(prefixes []netip.Prefix, addr netip.Addr) bool {
+	for _, p := range prefixes {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// IPInPrefix returns a random address within prefix p.
+func IPInPrefix(p netip.Prefix) netip.Addr {
+	return singleton.IPInPrefix(p)
+}
+
+// IPInPrefix returns a deterministic address within prefix p.
+// The network bits are kept, the host bits are random.
+// If p is invalid, the zero netip.Addr is returned.
+func (c *Chaos) IPInPrefix(p netip.Prefix) netip.Addr {
+	if !p.IsValid() {
+		return netip.Addr{}
+	}
+	p = p.Masked()
+
+	network := p.Addr().AsSlice()
+	random := make([]byte, len(network))
+	_, _ = c.rand().Read(random)
+
+	bits := p.Bits()
+	for i := range network {
+		switch {
+		case bits >= 8:
+			bits -= 8
+		case bits <= 0:
+			network[i] = random[i]
+		default:
+			mask := byte(0xff) >> bits
+			network[i] = network[i]&^mask | random[i]&mask
+			bits = 0
+		}
+	}
+
+	addr, _ := netip.AddrFromSlice(network)
+	return addr
+}
+
+// Prefix returns a random IPv4 prefix.
+func Prefix() netip.Prefix {
+	return singleton.Prefix()
+}
+
+// Prefix returns a deterministic IPv4 prefix with a length between 8 and 30 bits.
+// The returned prefix is always masked.
+func (c *Chaos) Prefix() netip.Prefix {
+	bits := c.IntBetween(8, 30)
+	return netip.PrefixFrom(c.IPv4(), bits).Masked()
+}
+
+// MAC returns a random MAC address.
+func MAC() net.HardwareAddr {
+	return singleton.MAC()
+}
+
+// MAC returns a deterministic 48-bit MAC address.
+// The address is a locally administered unicast address, so it never collides with real hardware.
+func (c *Chaos) MAC() net.HardwareAddr {
+	mac := make(net.HardwareAddr, 6)
+	_, _ = c.rand().Read(mac)
+	mac[0] = mac[0]&^0x01 | 0x02
+	return mac
+}
+
+// Port returns a random port number.
+func Port() uint16 {
+	return singleton.Port()
+}
+
+// Port returns a deterministic port number between 1 and 65535 (inclusive).
+func (c *Chaos) Port() uint16 {
+	return uint16(c.IntBetween(1, 65535))
+}
+
+var topLevelDomains = []string{
+	"com", "net", "org", "io", "dev", "app", "info", "biz", "fr", "de", "uk", "es", "it", "nl", "eu",
+}
+
+// Domain returns a random domain name.
+func Domain(tld string) string {
+	return singleton.Domain(tld)
+}
+
+// Domain returns a deterministic domain name under tld.
+// If tld is empty, a random top level domain is used.
+func (c *Chaos) Domain(tld string) string {
+	tld = strings.Trim(tld, ".")
+	if tld == "" {
+		tld = NewSliceProcessor[[]string](c).Item(topLevelDomains)
+	}
+	return c.Word() + "-" + c.Word() + "." + tld
+}
+
+// Hostname returns a random fully qualified hostname.
+func Hostname() string {
+	return singleton.Hostname()
+}
+
+// Hostname returns a deterministic fully qualified hostname.
+func (c *Chaos) Hostname() string {
+	return fmt.Sprintf("%s-%d.%s", c.Word(), c.Int(99), c.Domain(""))
+}
+
+// Email returns a random email address.
+func Email() string {
+	return singleton.Email()
+}
+
+// Email returns a deterministic email address.
+func (c *Chaos) Email() string {
+	return fmt.Sprintf("%s.%s%d@%s", c.Word(), c.Word(), c.Int(999), c.Domain(""))
+}
+
+// URLOptions configures the URLs generated by URL.
+type URLOptions struct {
+	// Scheme is the URL scheme. It defaults to "https".
+	Scheme string
+	// Host is the URL host. It defaults to a random hostname.
+	Host string
+	// PathSegments is the number of path segments.
+	PathSegments int
+	// QueryParams is the number of query parameters.
+	QueryParams int
+}
+
+// URL returns a random URL.
+func URL(opts URLOptions) string {
+	return singleton.URL(opts)
+}
+
+// URL returns a deterministic URL configured by opts.
+func (c *Chaos) URL(opts URLOptions) string {
+	u := url.URL{
+		Scheme: opts.Scheme,
+		Host:   opts.Host,
+	}
+	if u.Scheme == "" {
+		u.Scheme = "https"
+	}
+	if u.Host == "" {
+		u.Host = c.Hostname()
+	}
+
+	for i := 0; i < opts.PathSegments; i++ {
+		u.Path += "/" + c.Word()
+	}
+
+	if opts.QueryParams > 0 {
+		query := url.Values{}
+		for i := 0; i < opts.QueryParams; i++ {
+			query.Add(c.Word(), c.String(8))
+		}
+		u.RawQuery = query.Encode()
+	}
+
+	return u.String()
+}
+
+var userAgentFormats = []string{
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/%d.0.%d.%d Safari/537.36",
+	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/%d.0.%d.%d Safari/537.36",
+	"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/%d.0.%d.%d Safari/537.36",
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:%d.0) Gecko/20100101 Firefox/%[1]d.%d.%d",
+}
+
+// UserAgent returns a random HTTP user agent.
+func UserAgent() string {
+	return singleton.UserAgent()
+}
+
+// UserAgent returns a deterministic HTTP user agent mimicking common browsers.
+func (c *Chaos) UserAgent() string {
+	format := NewSliceProcessor[[]string](c).Item(userAgentFormats)
+	return fmt.Sprintf(format, c.IntBetween(90, 130), c.Int(9999), c.Int(999))
+}
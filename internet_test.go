@@ -0,0 +1,272 @@
+package chaos_test
+
+import (
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/raphoester/chaos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPv4(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.IPv4(), c.IPv4())
+	})
+
+	t.Run("different seeds produce unique results", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		results := make(map[netip.Addr]bool)
+		for i := 0; i < 1000; i++ {
+			result := c.IPv4()
+			assert.True(t, result.Is4())
+			assert.False(t, results[result], "Expected unique result for each seed")
+			results[result] = true
+		}
+	})
+
+	t.Run("respects scopes", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 1000; i++ {
+			assert.True(t, c.IPv4In(chaos.PrivateIPScope).IsPrivate())
+			assert.True(t, c.IPv4In(chaos.LoopbackIPScope).IsLoopback())
+
+			public := c.IPv4In(chaos.PublicIPScope)
+			assert.True(t, public.IsGlobalUnicast(), public.String())
+			assert.False(t, public.IsPrivate(), public.String())
+
+			doc := c.IPv4In(chaos.DocumentationIPScope)
+			assert.True(t, netip.MustParsePrefix("192.0.2.0/24").Contains(doc) ||
+				netip.MustParsePrefix("198.51.100.0/24").Contains(doc) ||
+				netip.MustParsePrefix("203.0.113.0/24").Contains(doc), doc.String())
+		}
+	})
+}
+
+func TestIPv6(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.IPv6(), c.IPv6())
+	})
+
+	t.Run("produces IPv6 addresses", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 1000; i++ {
+			result := c.IPv6()
+			assert.True(t, result.Is6())
+			assert.False(t, result.Is4In6())
+		}
+	})
+
+	t.Run("respects scopes", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 1000; i++ {
+			assert.True(t, c.IPv6In(chaos.PrivateIPScope).IsPrivate())
+			assert.True(t, c.IPv6In(chaos.LoopbackIPScope).IsLoopback())
+			assert.True(t, netip.MustParsePrefix("2001:db8::/32").Contains(c.IPv6In(chaos.DocumentationIPScope)))
+
+			public := c.IPv6In(chaos.PublicIPScope)
+			assert.True(t, public.IsGlobalUnicast(), public.String())
+			for _, special := range []string{"2001::/23", "2001:db8::/32", "2002::/16", "3fff::/20"} {
+				assert.False(t, netip.MustParsePrefix(special).Contains(public), public.String())
+			}
+		}
+	})
+}
+
+func TestIPInPrefix(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		p := netip.MustParsePrefix("10.1.0.0/16")
+		assert.Equal(t, c.IPInPrefix(p), c.IPInPrefix(p))
+	})
+
+	t.Run("stays within the prefix", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		prefixes := []netip.Prefix{
+			netip.MustParsePrefix("10.1.0.0/16"),
+			netip.MustParsePrefix("192.168.1.128/25"),
+			netip.MustParsePrefix("172.16.0.0/13"),
+			netip.MustParsePrefix("2001:db8:abcd::/48"),
+			netip.MustParsePrefix("fe80::/10"),
+		}
+		for _, p := range prefixes {
+			for i := 0; i < 100; i++ {
+				assert.True(t, p.Contains(c.IPInPrefix(p)))
+			}
+		}
+	})
+
+	t.Run("edge case: full length prefix", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := netip.MustParsePrefix("192.168.1.1/32")
+		assert.Equal(t, p.Addr(), c.IPInPrefix(p))
+	})
+
+	t.Run("edge case: invalid prefix", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		assert.False(t, c.IPInPrefix(netip.Prefix{}).IsValid())
+	})
+}
+
+func TestPrefix(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.Prefix(), c.Prefix())
+	})
+
+	t.Run("produces masked prefixes", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 1000; i++ {
+			p := c.Prefix()
+			assert.Equal(t, p.Masked(), p)
+			assert.GreaterOrEqual(t, p.Bits(), 8)
+			assert.LessOrEqual(t, p.Bits(), 30)
+		}
+	})
+}
+
+func TestMAC(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.MAC(), c.MAC())
+	})
+
+	t.Run("produces locally administered unicast addresses", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 1000; i++ {
+			mac := c.MAC()
+			require.Len(t, mac, 6)
+			assert.Zero(t, mac[0]&0x01)
+			assert.NotZero(t, mac[0]&0x02)
+		}
+	})
+}
+
+func TestPort(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.Port(), c.Port())
+	})
+
+	t.Run("never returns zero", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 1000; i++ {
+			assert.NotZero(t, c.Port())
+		}
+	})
+}
+
+func TestDomain(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.Domain("com"), c.Domain("com"))
+	})
+
+	t.Run("uses the given top level domain", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		assert.True(t, strings.HasSuffix(c.Domain("org"), ".org"))
+		assert.True(t, strings.HasSuffix(c.Domain(".co.uk"), ".co.uk"))
+	})
+
+	t.Run("picks a top level domain when empty", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		assert.Contains(t, c.Domain(""), ".")
+	})
+}
+
+func TestHostname(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.Hostname(), c.Hostname())
+	})
+
+	t.Run("produces valid hostnames", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 100; i++ {
+			u, err := url.Parse("https://" + c.Hostname())
+			require.NoError(t, err)
+			assert.Len(t, strings.Split(u.Hostname(), "."), 3)
+		}
+	})
+}
+
+func TestEmail(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.Email(), c.Email())
+	})
+
+	t.Run("produces valid addresses", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 100; i++ {
+			email := c.Email()
+			addr, err := mail.ParseAddress(email)
+			require.NoError(t, err)
+			assert.Equal(t, email, addr.Address)
+		}
+	})
+}
+
+func TestURL(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		opts := chaos.URLOptions{PathSegments: 2, QueryParams: 2}
+		assert.Equal(t, c.URL(opts), c.URL(opts))
+	})
+
+	t.Run("respects options", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		u, err := url.Parse(c.URL(chaos.URLOptions{
+			Scheme:       "http",
+			Host:         "example.com:8080",
+			PathSegments: 3,
+			QueryParams:  2,
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "http", u.Scheme)
+		assert.Equal(t, "example.com:8080", u.Host)
+		assert.Len(t, strings.Split(strings.TrimPrefix(u.Path, "/"), "/"), 3)
+		assert.NotEmpty(t, u.Query())
+	})
+
+	t.Run("defaults to https and a random host", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		u, err := url.Parse(c.URL(chaos.URLOptions{}))
+		require.NoError(t, err)
+		assert.Equal(t, "https", u.Scheme)
+		assert.NotEmpty(t, u.Host)
+		assert.Empty(t, u.Path)
+	})
+}
+
+func TestUserAgent(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.UserAgent(), c.UserAgent())
+	})
+
+	t.Run("looks like a browser user agent", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 100; i++ {
+			ua := c.UserAgent()
+			assert.True(t, strings.HasPrefix(ua, "Mozilla/5.0 ("), ua)
+			assert.NotContains(t, ua, "%!")
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package chaos
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+)
+
+// NewMapProcessor returns a new MapProcessor.
+func NewMapProcessor[M ~map[K]V, K comparable, V any](c *Chaos) *MapProcessor[M, K, V] {
+	return &MapProcessor[M, K, V]{
+		c: c,
+	}
+}
+
+// MapProcessor is a helper to extract values from maps.
+// Go randomizes map iteration order, so keys are sorted before any selection:
+// the same map always gives the same results for the same seed.
+// Pointers and channels are ordered by address: for a given seed, the same map gives the same
+// results within a process, but they may change from one run to the next.
+type MapProcessor[M ~map[K]V, K comparable, V any] struct {
+	c *Chaos
+}
+
+// sortedKeys returns the keys of m in a deterministic order, see compareValues.
+func sortedKeys[M ~map[K]V, K comparable, V any](m M) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortStableFunc(keys, func(a, b K) int {
+		return compareValues(reflect.ValueOf(&a).Elem(), reflect.ValueOf(&b).Elem())
+	})
+	return keys
+}
+
+// compareValues is a total order over comparable values: values are ordered by kind, then by
+// type, then by value. Basic kinds use their natural order, NaN being the smallest float, and
+// arrays, structs and interfaces are ordered by their elements, fields and dynamic values.
+// Pointers and channels, which have no other order, are ordered by address.
+func compareValues(a, b reflect.Value) int {
+	if a.Kind() != b.Kind() {
+		return cmp.Compare(a.Kind(), b.Kind())
+	}
+	if a.Type() != b.Type() {
+		return cmp.Or(
+			cmp.Compare(a.Type().PkgPath(), b.Type().PkgPath()),
+			cmp.Compare(a.Type().String(), b.Type().String()),
+		)
+	}
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.Complex64, reflect.Complex128:
+		return cmp.Or(cmp.Compare(real(a.Complex()), real(b.Complex())), cmp.Compare(imag(a.Complex()), imag(b.Complex())))
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case reflect.Bool:
+		return compareBools(a.Bool(), b.Bool())
+	case reflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			if c := compareValues(a.Index(i), b.Index(i)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if c := compareValues(a.Field(i), b.Field(i)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return compareBools(!a.IsNil(), !b.IsNil())
+		}
+		return compareValues(a.Elem(), b.Elem())
+	default:
+		// pointers, unsafe pointers and channels: the other kinds are not comparable
+		return cmp.Compare(a.Pointer(), b.Pointer())
+	}
+}
+
+// MapKey returns a random key of the map.
+func MapKey[M ~map[K]V, K comparable, V any](m M) K {
+	return NewMapProcessor[M, K, V](singleton).Key(m)
+}
+
+// Key returns a random key of the map.
+// If the map is empty, it returns the zero value.
+func (p *MapProcessor[M, K, V]) Key(m M) K {
+	return NewSliceProcessor[[]K](p.c).Item(sortedKeys(m))
+}
+
+// MapValue returns a random value of the map.
+func MapValue[M ~map[K]V, K comparable, V any](m M) V {
+	return NewMapProcessor[M, K, V](singleton).Value(m)
+}
+
+// Value returns the value of a random key of the map.
+// If the map is empty, it returns the zero value.
+func (p *MapProcessor[M, K, V]) Value(m M) V {
+	_, v := p.Entry(m)
+	return v
+}
+
+// MapEntry returns a random entry of the map.
+func MapEntry[M ~map[K]V, K comparable, V any](m M) (K, V) {
+	return NewMapProcessor[M, K, V](singleton).Entry(m)
+}
+
+// Entry returns a random key of the map with its value.
+// If the map is empty, it returns zero values.
+func (p *MapProcessor[M, K, V]) Entry(m M) (K, V) {
+	k := p.Key(m)
+	return k, m[k]
+}
+
+// UniqueMapKeys returns n distinct random keys of the map.
+func UniqueMapKeys[M ~map[K]V, K comparable, V any](m M, n int) ([]K, error) {
+	return NewMapProcessor[M, K, V](singleton).UniqueKeys(m, n)
+}
+
+// UniqueKeys returns n distinct random keys of the map.
+// If the map has less than n keys, it returns an error.
+func (p *MapProcessor[M, K, V]) UniqueKeys(m M, n int) ([]K, error) {
+	return NewSliceProcessor[[]K](p.c).UniqueItems(sortedKeys(m), n)
+}
+
+// MapOf returns a map of n entries, with keys generated by keyGen and values by valGen.
+// Keys are generated again when they collide. If keyGen cannot produce enough distinct keys
+// (1000 collisions in a row), the returned map has less than n entries.
+func MapOf[K comparable, V any](c *Chaos, n int, keyGen func(c *Chaos) K, valGen func(c *Chaos) V) map[K]V {
+	m := make(map[K]V, max(n, 0))
+	for collisions := 0; len(m) < n && collisions < maxUniqueRetries; {
+		k := keyGen(c)
+		if _, ok := m[k]; ok {
+			collisions++
+			continue
+		}
+		collisions = 0
+		m[k] = valGen(c)
+	}
+	return m
+}
+
+// SetOf returns a set of n items generated by gen.
+// Items are generated again when they collide. If gen cannot produce enough distinct items
+// (1000 collisions in a row), the returned set has less than n items.
+func SetOf[K comparable](c *Chaos, n int, gen func(c *Chaos) K) map[K]struct{} {
+	return MapOf(c, n, gen, func(*Chaos) struct{} {
+		return struct{}{}
+	})
+}
+
+// compareBools orders false before true.
+func compareBools(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case !a:
+		return -1
+	default:
+		return 1
+	}
+}
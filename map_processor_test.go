@@ -0,0 +1,167 @@
+package chaos_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/raphoester/chaos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapKey(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5}
+
+	t.Run("deterministic across map instances", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			copied := make(map[string]int)
+			for k, v := range m {
+				copied[k] = v
+			}
+			p1 := chaos.NewMapProcessor[map[string]int](chaos.New(t.Name()))
+			p2 := chaos.NewMapProcessor[map[string]int](chaos.New(t.Name()))
+			assert.Equal(t, p1.Key(m), p2.Key(copied))
+		}
+	})
+
+	t.Run("selects from all keys", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewMapProcessor[map[string]int](c)
+		selected := make(map[string]bool)
+		for i := 0; i < 1000; i++ {
+			selected[p.Key(m)] = true
+		}
+		assert.Len(t, selected, len(m))
+	})
+
+	t.Run("entries match the map", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewMapProcessor[map[string]int](c)
+		for i := 0; i < 100; i++ {
+			k, v := p.Entry(m)
+			assert.Equal(t, m[k], v)
+			assert.Contains(t, []int{1, 2, 3, 4, 5}, p.Value(m))
+		}
+	})
+
+	t.Run("sorts keys of any comparable type", func(t *testing.T) {
+		type key struct {
+			A string
+			B int
+		}
+		structMap := map[key]bool{{"a", 1}: true, {"b", 2}: true, {"a", 2}: false}
+		p1 := chaos.NewMapProcessor[map[key]bool](chaos.New(t.Name()))
+		p2 := chaos.NewMapProcessor[map[key]bool](chaos.New(t.Name()))
+		for i := 0; i < 100; i++ {
+			assert.Equal(t, p1.Key(structMap), p2.Key(structMap))
+		}
+	})
+
+	t.Run("orders interface keys of different types", func(t *testing.T) {
+		type id int
+		keys := []any{1, int64(1), id(1), "1", 1.5, nil, [2]int{1, 2}, struct{ A any }{A: 1}}
+		for i := 0; i < 100; i++ {
+			m1, m2 := make(map[any]int), make(map[any]int)
+			for j := range keys {
+				m1[keys[j]] = j
+				m2[keys[len(keys)-1-j]] = len(keys) - 1 - j
+			}
+			k1, err1 := chaos.NewMapProcessor[map[any]int](chaos.New(t.Name())).UniqueKeys(m1, len(keys))
+			k2, err2 := chaos.NewMapProcessor[map[any]int](chaos.New(t.Name())).UniqueKeys(m2, len(keys))
+			require.NoError(t, err1)
+			require.NoError(t, err2)
+			assert.Equal(t, k1, k2)
+		}
+	})
+
+	t.Run("orders NaN keys", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			m := map[float64]int{math.NaN(): 1, math.NaN(): 2, math.Inf(-1): 3, 0: 4, 1: 5}
+			k1, err1 := chaos.NewMapProcessor[map[float64]int](chaos.New(t.Name())).UniqueKeys(m, len(m))
+			k2, err2 := chaos.NewMapProcessor[map[float64]int](chaos.New(t.Name())).UniqueKeys(m, len(m))
+			require.NoError(t, err1)
+			require.NoError(t, err2)
+			assert.Equal(t, fmt.Sprint(k1), fmt.Sprint(k2))
+		}
+	})
+
+	t.Run("orders pointer keys by address", func(t *testing.T) {
+		values := make([]int, 50)
+		m := make(map[*int]bool)
+		for i := range values {
+			m[&values[i]] = true
+		}
+		for i := 0; i < 20; i++ {
+			p1 := chaos.NewMapProcessor[map[*int]bool](chaos.New(t.Name()))
+			p2 := chaos.NewMapProcessor[map[*int]bool](chaos.New(t.Name()))
+			assert.Same(t, p1.Key(m), p2.Key(m))
+		}
+	})
+
+	t.Run("returns zero values for empty map", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewMapProcessor[map[string]int](c)
+		k, v := p.Entry(map[string]int{})
+		assert.Equal(t, "", k)
+		assert.Equal(t, 0, v)
+	})
+}
+
+func TestUniqueMapKeys(t *testing.T) {
+	m := map[int]string{1: "a", 2: "b", 3: "c", 4: "d"}
+
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		p := chaos.NewMapProcessor[map[int]string](c)
+		result1, err1 := p.UniqueKeys(m, 2)
+		result2, err2 := p.UniqueKeys(m, 2)
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.Equal(t, result1, result2)
+	})
+
+	t.Run("returns distinct keys", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewMapProcessor[map[int]string](c)
+		result, err := p.UniqueKeys(m, 4)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []int{1, 2, 3, 4}, result)
+	})
+
+	t.Run("returns error when not enough keys", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewMapProcessor[map[int]string](c)
+		_, err := p.UniqueKeys(m, 5)
+		assert.ErrorIs(t, err, chaos.ErrNotEnoughItemsInSlice)
+	})
+}
+
+func TestMapOf(t *testing.T) {
+	keyGen := func(c *chaos.Chaos) string { return c.String(4) }
+	valGen := func(c *chaos.Chaos) int { return c.Int(100) }
+
+	t.Run("deterministic output", func(t *testing.T) {
+		m1 := chaos.MapOf(chaos.New(t.Name()), 10, keyGen, valGen)
+		m2 := chaos.MapOf(chaos.New(t.Name()), 10, keyGen, valGen)
+		assert.Equal(t, m1, m2)
+	})
+
+	t.Run("respects size", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		assert.Len(t, chaos.MapOf(c, 50, keyGen, valGen), 50)
+	})
+
+	t.Run("stops when keys are exhausted", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		m := chaos.MapOf(c, 10, func(c *chaos.Chaos) int { return c.Int(2) }, valGen)
+		assert.Len(t, m, 3)
+	})
+
+	t.Run("builds sets", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		set := chaos.SetOf(c, 20, func(c *chaos.Chaos) int { return c.Int(1000) })
+		assert.Len(t, set, 20)
+	})
+}
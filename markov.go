@@ -0,0 +1,113 @@
+package chaos
+
+import (
+	"strings"
+)
+
+// MarkovChain is a word-level Markov chain trained from a text corpus.
+// It is used by MarkovProcessor to generate text with a realistic token distribution.
+type MarkovChain struct {
+	order       int
+	starts      [][]string
+	transitions map[string][]string
+	words       []string
+}
+
+// NewMarkovChain trains a Markov chain of the given order from corpus.
+// The order is the number of previous words used to pick the next one.
+// Behavior:
+//   - If order < 1, an order of 1 is used.
+//   - Sentences are delimited by words ending with '.', '!' or '?'.
+func NewMarkovChain(corpus string, order int) *MarkovChain {
+	if order < 1 {
+		order = 1
+	}
+	m := &MarkovChain{
+		order:       order,
+		transitions: make(map[string][]string),
+	}
+
+	tokens := strings.Fields(corpus)
+	m.words = tokens
+	sentenceStart := true
+	for i, token := range tokens {
+		if sentenceStart && i+order <= len(tokens) {
+			m.starts = append(m.starts, tokens[i:i+order])
+		}
+		sentenceStart = strings.ContainsAny(token[len(token)-1:], ".!?")
+
+		if i+order < len(tokens) {
+			key := markovKey(tokens[i : i+order])
+			m.transitions[key] = append(m.transitions[key], tokens[i+order])
+		}
+	}
+
+	return m
+}
+
+func markovKey(words []string) string {
+	return strings.Join(words, " ")
+}
+
+func (m *MarkovChain) next(c *Chaos, previous []string) string {
+	if len(m.words) == 0 {
+		return loremSource{}.next(c, previous)
+	}
+
+	if len(previous) >= m.order {
+		key := markovKey(previous[len(previous)-m.order:])
+		if candidates := m.transitions[key]; len(candidates) > 0 {
+			return candidates[c.Int(len(candidates)-1)]
+		}
+	}
+
+	// Either the sentence just started, or we are in the middle of a start prefix,
+	// or the chain reached a dead end: continue from a sentence start.
+	if len(m.starts) == 0 {
+		return m.words[c.Int(len(m.words)-1)]
+	}
+	if len(previous) > 0 && len(previous) < m.order {
+		var matching [][]string
+		for _, start := range m.starts {
+			if markovKey(start[:len(previous)]) == markovKey(previous) {
+				matching = append(matching, start)
+			}
+		}
+		if len(matching) > 0 {
+			return matching[c.Int(len(matching)-1)][len(previous)]
+		}
+	}
+	return m.starts[c.Int(len(m.starts)-1)][0]
+}
+
+// NewMarkovProcessor returns a new MarkovProcessor generating text from chain.
+func NewMarkovProcessor(c *Chaos, chain *MarkovChain) *MarkovProcessor {
+	return &MarkovProcessor{
+		gen: textGenerator{c: c, source: chain},
+	}
+}
+
+// MarkovProcessor is a helper to generate text following a MarkovChain.
+type MarkovProcessor struct {
+	gen textGenerator
+}
+
+// Sentence returns a sentence of minWords to maxWords words.
+func (p *MarkovProcessor) Sentence(minWords, maxWords int) string {
+	return p.gen.sentence(minWords, maxWords)
+}
+
+// Paragraph returns a paragraph made of n sentences.
+func (p *MarkovProcessor) Paragraph(n int) string {
+	return p.gen.paragraph(n)
+}
+
+// Text returns a text of at most maxChars characters.
+func (p *MarkovProcessor) Text(maxChars int) string {
+	return p.gen.text(maxChars)
+}
+
+// Title returns a title of minWords to maxWords capitalized words.
+func (p *MarkovProcessor) Title(minWords, maxWords int) string {
+	return p.gen.title(minWords, maxWords)
+}
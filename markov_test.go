@@ -0,0 +1,47 @@
+package chaos_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/raphoester/chaos"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkovProcessor(t *testing.T) {
+	const corpus = "The cat sat on the mat. The dog sat on the rug. " +
+		"A cat chased the dog. The dog chased a ball. The cat slept on the mat."
+
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		p := chaos.NewMarkovProcessor(c, chaos.NewMarkovChain(corpus, 1))
+		assert.Equal(t, p.Sentence(3, 8), p.Sentence(3, 8))
+	})
+
+	t.Run("only uses words from the corpus", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewMarkovProcessor(c, chaos.NewMarkovChain(corpus, 2))
+		known := make(map[string]bool)
+		for _, word := range strings.Fields(corpus) {
+			known[strings.ToLower(strings.Trim(word, "."))] = true
+		}
+		for i := 0; i < 100; i++ {
+			for _, word := range strings.Fields(p.Sentence(2, 10)) {
+				assert.True(t, known[strings.ToLower(strings.Trim(word, "."))], "unexpected word %q", word)
+			}
+		}
+	})
+
+	t.Run("follows observed transitions", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewMarkovProcessor(c, chaos.NewMarkovChain("one two three four five.", 1))
+		assert.Equal(t, "One two three four five.", p.Sentence(5, 5))
+	})
+
+	t.Run("edge case: empty corpus falls back to lorem ipsum", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewMarkovProcessor(c, chaos.NewMarkovChain("", 1))
+		assert.NotEmpty(t, p.Sentence(1, 5))
+	})
+}
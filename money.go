@@ -0,0 +1,180 @@
+package chaos
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ISOCurrency is a currency as described by ISO 4217.
+type ISOCurrency struct {
+	// Code is the alphabetic code of the currency, like "EUR".
+	Code string
+	// Numeric is the numeric code of the currency, like "978".
+	Numeric string
+	// Exponent is the number of digits after the decimal separator of the minor unit.
+	Exponent int
+	// Name is the English name of the currency.
+	Name string
+}
+
+// isoCurrencies is a subset of the ISO 4217 table, sorted by code.
+var isoCurrencies = []ISOCurrency{
+	{Code: "AED", Numeric: "784", Exponent: 2, Name: "UAE Dirham"},
+	{Code: "ARS", Numeric: "032", Exponent: 2, Name: "Argentine Peso"},
+	{Code: "AUD", Numeric: "036", Exponent: 2, Name: "Australian Dollar"},
+	{Code: "BHD", Numeric: "048", Exponent: 3, Name: "Bahraini Dinar"},
+	{Code: "BRL", Numeric: "986", Exponent: 2, Name: "Brazilian Real"},
+	{Code: "CAD", Numeric: "124", Exponent: 2, Name: "Canadian Dollar"},
+	{Code: "CHF", Numeric: "756", Exponent: 2, Name: "Swiss Franc"},
+	{Code: "CLP", Numeric: "152", Exponent: 0, Name: "Chilean Peso"},
+	{Code: "CNY", Numeric: "156", Exponent: 2, Name: "Yuan Renminbi"},
+	{Code: "CZK", Numeric: "203", Exponent: 2, Name: "Czech Koruna"},
+	{Code: "DKK", Numeric: "208", Exponent: 2, Name: "Danish Krone"},
+	{Code: "EGP", Numeric: "818", Exponent: 2, Name: "Egyptian Pound"},
+	{Code: "EUR", Numeric: "978", Exponent: 2, Name: "Euro"},
+	{Code: "GBP", Numeric: "826", Exponent: 2, Name: "Pound Sterling"},
+	{Code: "HKD", Numeric: "344", Exponent: 2, Name: "Hong Kong Dollar"},
+	{Code: "HUF", Numeric: "348", Exponent: 2, Name: "Forint"},
+	{Code: "IDR", Numeric: "360", Exponent: 2, Name: "Rupiah"},
+	{Code: "ILS", Numeric: "376", Exponent: 2, Name: "New Israeli Sheqel"},
+	{Code: "INR", Numeric: "356", Exponent: 2, Name: "Indian Rupee"},
+	{Code: "ISK", Numeric: "352", Exponent: 0, Name: "Iceland Krona"},
+	{Code: "JOD", Numeric: "400", Exponent: 3, Name: "Jordanian Dinar"},
+	{Code: "JPY", Numeric: "392", Exponent: 0, Name: "Yen"},
+	{Code: "KRW", Numeric: "410", Exponent: 0, Name: "Won"},
+	{Code: "KWD", Numeric: "414", Exponent: 3, Name: "Kuwaiti Dinar"},
+	{Code: "MAD", Numeric: "504", Exponent: 2, Name: "Moroccan Dirham"},
+	{Code: "MXN", Numeric: "484", Exponent: 2, Name: "Mexican Peso"},
+	{Code: "NGN", Numeric: "566", Exponent: 2, Name: "Naira"},
+	{Code: "NOK", Numeric: "578", Exponent: 2, Name: "Norwegian Krone"},
+	{Code: "NZD", Numeric: "554", Exponent: 2, Name: "New Zealand Dollar"},
+	{Code: "OMR", Numeric: "512", Exponent: 3, Name: "Rial Omani"},
+	{Code: "PLN", Numeric: "985", Exponent: 2, Name: "Zloty"},
+	{Code: "SAR", Numeric: "682", Exponent: 2, Name: "Saudi Riyal"},
+	{Code: "SEK", Numeric: "752", Exponent: 2, Name: "Swedish Krona"},
+	{Code: "SGD", Numeric: "702", Exponent: 2, Name: "Singapore Dollar"},
+	{Code: "THB", Numeric: "764", Exponent: 2, Name: "Baht"},
+	{Code: "TND", Numeric: "788", Exponent: 3, Name: "Tunisian Dinar"},
+	{Code: "TRY", Numeric: "949", Exponent: 2, Name: "Turkish Lira"},
+	{Code: "UGX", Numeric: "800", Exponent: 0, Name: "Uganda Shilling"},
+	{Code: "USD", Numeric: "840", Exponent: 2, Name: "US Dollar"},
+	{Code: "VND", Numeric: "704", Exponent: 0, Name: "Dong"},
+	{Code: "XAF", Numeric: "950", Exponent: 0, Name: "CFA Franc BEAC"},
+	{Code: "XOF", Numeric: "952", Exponent: 0, Name: "CFA Franc BCEAO"},
+	{Code: "ZAR", Numeric: "710", Exponent: 2, Name: "Rand"},
+}
+
+var (
+	ErrUnknownCurrency  = errors.New("unknown currency")
+	ErrAmountOutOfRange = errors.New("amount out of range")
+)
+
+// CurrencyByCode returns the ISO 4217 currency with the given alphabetic code.
+// If the currency is not part of the embedded table, it returns an error.
+func CurrencyByCode(code string) (ISOCurrency, error) {
+	code = strings.ToUpper(code)
+	for _, currency := range isoCurrencies {
+		if currency.Code == code {
+			return currency, nil
+		}
+	}
+	return ISOCurrency{}, errors.Join(ErrUnknownCurrency,
+		fmt.Errorf("no ISO 4217 currency with code %q", code))
+}
+
+// Currency returns a random ISO 4217 currency.
+func Currency() ISOCurrency {
+	return singleton.Currency()
+}
+
+// Currency returns a deterministic ISO 4217 currency from the embedded table.
+func (c *Chaos) Currency() ISOCurrency {
+	return NewSliceProcessor[[]ISOCurrency](c).Item(isoCurrencies)
+}
+
+// Amount is an amount of money, stored as an integer number of minor units.
+type Amount struct {
+	// Minor is the amount in minor units of the currency (cents for EUR, yen for JPY).
+	Minor    int64
+	Currency ISOCurrency
+}
+
+// Decimal formats the amount in major units, with as many decimals as the currency exponent.
+func (a Amount) Decimal() string {
+	return FormatMinorUnits(a.Minor, a.Currency.Exponent)
+}
+
+// String formats the amount followed by its currency code, like "12.34 EUR".
+func (a Amount) String() string {
+	return a.Decimal() + " " + a.Currency.Code
+}
+
+// FormatMinorUnits formats an amount of minor units in major units with exponent decimals.
+// For example, FormatMinorUnits(-1234, 2) returns "-12.34".
+func FormatMinorUnits(minor int64, exponent int) string {
+	sign := ""
+	digits := strconv.FormatUint(uint64(minor), 10)
+	if minor < 0 {
+		sign = "-"
+		digits = strconv.FormatUint(uint64(-minor), 10)
+	}
+	if exponent <= 0 {
+		return sign + digits
+	}
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	cut := len(digits) - exponent
+	return sign + digits[:cut] + "." + digits[cut:]
+}
+
+// Money returns a random amount of money.
+func Money(currencyCode string, min, max float64) (Amount, error) {
+	return singleton.Money(currencyCode, min, max)
+}
+
+// Money returns a deterministic amount of money of currencyCode between min and max major units.
+// Behavior:
+//   - The amount is an integer number of minor units respecting the currency exponent,
+//     so Money("JPY", ...) never has decimals and Money("KWD", ...) has at most 3.
+//   - min and max are rounded to the nearest minor unit.
+//   - If min > max, the values are swapped.
+//   - If the currency is unknown, it returns an error.
+//   - If min or max is not a number, or does not fit in an int64 of minor units, it returns an error.
+func (c *Chaos) Money(currencyCode string, min, max float64) (Amount, error) {
+	currency, err := CurrencyByCode(currencyCode)
+	if err != nil {
+		return Amount{}, err
+	}
+	scale := math.Pow10(currency.Exponent)
+	minMinor, err := minorUnits(min, scale)
+	if err != nil {
+		return Amount{}, err
+	}
+	maxMinor, err := minorUnits(max, scale)
+	if err != nil {
+		return Amount{}, err
+	}
+	if minMinor > maxMinor {
+		minMinor, maxMinor = maxMinor, minMinor
+	}
+	return Amount{
+		Minor:    c.int64Between(minMinor, maxMinor),
+		Currency: currency,
+	}, nil
+}
+
+// minorUnits returns amount major units in minor units, scale being the number of minor units per major unit.
+// If the result is not a number or does not fit in an int64, it returns an error.
+func minorUnits(amount, scale float64) (int64, error) {
+	minor := math.Round(amount * scale)
+	// -2^63 is exactly representable, while MaxInt64 rounds up to 2^63, which does not fit.
+	if math.IsNaN(minor) || minor < math.MinInt64 || minor >= math.MaxInt64 {
+		return 0, errors.Join(ErrAmountOutOfRange,
+			fmt.Errorf("%v does not fit in int64 minor units", amount))
+	}
+	return int64(minor), nil
+}
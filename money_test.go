@@ -0,0 +1,120 @@
+package chaos_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/raphoester/chaos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrency(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.Currency(), c.Currency())
+	})
+
+	t.Run("picks currencies from the ISO 4217 table", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 100; i++ {
+			currency := c.Currency()
+			found, err := chaos.CurrencyByCode(currency.Code)
+			require.NoError(t, err)
+			assert.Equal(t, currency, found)
+			assert.Len(t, currency.Numeric, 3)
+		}
+	})
+
+	t.Run("returns error for unknown currency", func(t *testing.T) {
+		_, err := chaos.CurrencyByCode("XYZ")
+		assert.ErrorIs(t, err, chaos.ErrUnknownCurrency)
+	})
+
+}
+
+func TestMoney(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		amount1, err1 := c.Money("EUR", 10, 100)
+		amount2, err2 := c.Money("EUR", 10, 100)
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.Equal(t, amount1, amount2)
+	})
+
+	t.Run("respects bounds in minor units", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 1000; i++ {
+			amount, err := c.Money("EUR", 10, 100)
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, amount.Minor, int64(1000))
+			assert.LessOrEqual(t, amount.Minor, int64(10000))
+		}
+	})
+
+	t.Run("respects currency exponent", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		cases := map[string]int{"JPY": 0, "EUR": 2, "KWD": 3}
+		for code, exponent := range cases {
+			for i := 0; i < 100; i++ {
+				amount, err := c.Money(code, 1, 1000)
+				require.NoError(t, err)
+				decimal := amount.Decimal()
+				if exponent == 0 {
+					assert.NotContains(t, decimal, ".")
+				} else {
+					assert.Len(t, decimal[strings.Index(decimal, ".")+1:], exponent)
+				}
+			}
+		}
+	})
+
+	t.Run("returns error for unknown currency", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		_, err := c.Money("XYZ", 1, 10)
+		assert.ErrorIs(t, err, chaos.ErrUnknownCurrency)
+	})
+
+	t.Run("returns error for amounts out of range", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for _, bounds := range [][2]float64{{0, 1e30}, {-1e30, 0}, {0, math.NaN()}, {math.Inf(-1), 0}} {
+			_, err := c.Money("EUR", bounds[0], bounds[1])
+			assert.ErrorIs(t, err, chaos.ErrAmountOutOfRange, bounds)
+		}
+	})
+
+	t.Run("edge case: span wider than int64", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 100; i++ {
+			amount, err := c.Money("EUR", -9e16, 9e16)
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, amount.Minor, int64(-9e18))
+			assert.LessOrEqual(t, amount.Minor, int64(9e18))
+		}
+	})
+}
+
+func TestFormatMinorUnits(t *testing.T) {
+	cases := []struct {
+		minor    int64
+		exponent int
+		expected string
+	}{
+		{1234, 2, "12.34"},
+		{-1234, 2, "-12.34"},
+		{5, 2, "0.05"},
+		{0, 3, "0.000"},
+		{1234, 0, "1234"},
+		{1234567, 3, "1234.567"},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, chaos.FormatMinorUnits(tc.minor, tc.exponent))
+	}
+
+	amount := chaos.Amount{Minor: 1999, Currency: chaos.ISOCurrency{Code: "EUR", Exponent: 2}}
+	assert.Equal(t, "19.99 EUR", amount.String())
+}
@@ -0,0 +1,139 @@
+package chaos_test
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/raphoester/chaos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var strictPolicy = chaos.PasswordPolicy{
+	MinLength:  12,
+	MaxLength:  20,
+	MinUpper:   1,
+	MinLower:   1,
+	MinDigits:  1,
+	MinSymbols: 1,
+	NoRepeats:  true,
+}
+
+func TestPassword(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		password1, err1 := c.Password(strictPolicy)
+		password2, err2 := c.Password(strictPolicy)
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.Equal(t, password1, password2)
+	})
+
+	t.Run("satisfies the policy", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 1000; i++ {
+			password, err := c.Password(strictPolicy)
+			require.NoError(t, err)
+			assert.Empty(t, strictPolicy.Violations(password), password)
+		}
+	})
+
+	t.Run("does not use symbols unless required", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 100; i++ {
+			password, err := c.Password(chaos.PasswordPolicy{MinLength: 16})
+			require.NoError(t, err)
+			assert.Regexp(t, `^[a-zA-Z0-9]{16,24}$`, password)
+		}
+	})
+
+	t.Run("returns error for contradictory policy", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		_, err := c.Password(chaos.PasswordPolicy{MaxLength: 2, MinUpper: 2, MinDigits: 2})
+		assert.ErrorIs(t, err, chaos.ErrUnsatisfiablePolicy)
+	})
+}
+
+func TestPasswordViolating(t *testing.T) {
+	rules := []chaos.PasswordRule{
+		chaos.RuleMinLength,
+		chaos.RuleMaxLength,
+		chaos.RuleMinUpper,
+		chaos.RuleMinLower,
+		chaos.RuleMinDigits,
+		chaos.RuleMinSymbols,
+		chaos.RuleNoRepeats,
+	}
+
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		password1, err1 := c.PasswordViolating(strictPolicy, chaos.RuleMinDigits)
+		password2, err2 := c.PasswordViolating(strictPolicy, chaos.RuleMinDigits)
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.Equal(t, password1, password2)
+	})
+
+	t.Run("violates exactly the chosen rule", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for _, rule := range rules {
+			for i := 0; i < 100; i++ {
+				password, err := c.PasswordViolating(strictPolicy, rule)
+				require.NoError(t, err, rule.String())
+				assert.Equal(t, []chaos.PasswordRule{rule}, strictPolicy.Violations(password), "%s: %s", rule, password)
+			}
+		}
+	})
+
+	t.Run("returns error when the rule cannot be violated", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		policy := chaos.PasswordPolicy{MinLength: 8}
+		for _, rule := range []chaos.PasswordRule{chaos.RuleMaxLength, chaos.RuleMinUpper, chaos.RuleNoRepeats} {
+			_, err := c.PasswordViolating(policy, rule)
+			assert.ErrorIs(t, err, chaos.ErrUnviolatableRule, rule.String())
+		}
+	})
+
+	t.Run("returns error when no repeat fits the other rules", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		policy := chaos.PasswordPolicy{MaxLength: 2, MinUpper: 1, MinLower: 1, NoRepeats: true}
+		_, err := c.PasswordViolating(policy, chaos.RuleNoRepeats)
+		assert.ErrorIs(t, err, chaos.ErrUnviolatableRule)
+		assert.NotErrorIs(t, err, chaos.ErrUnsatisfiablePolicy)
+	})
+}
+
+func TestTokens(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.HexToken(16), c.HexToken(16))
+		assert.Equal(t, c.Base64URLToken(16), c.Base64URLToken(16))
+		assert.Equal(t, c.APIKey("sk_", 24), c.APIKey("sk_", 24))
+	})
+
+	t.Run("hex tokens decode to n bytes", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		decoded, err := hex.DecodeString(c.HexToken(32))
+		require.NoError(t, err)
+		assert.Len(t, decoded, 32)
+	})
+
+	t.Run("base64url tokens decode to n bytes", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		decoded, err := base64.RawURLEncoding.DecodeString(c.Base64URLToken(32))
+		require.NoError(t, err)
+		assert.Len(t, decoded, 32)
+	})
+
+	t.Run("API keys are prefixed", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		key := c.APIKey("sk_live_", 24)
+		assert.True(t, strings.HasPrefix(key, "sk_live_"))
+		assert.Regexp(t, `^sk_live_[a-zA-Z0-9]{24}$`, key)
+	})
+}
@@ -0,0 +1,95 @@
+package chaos
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// permutationRounds is the number of rounds of the Feistel network.
+const permutationRounds = 6
+
+// Permutation is a seed-dependent permutation of the integers in [0, n).
+// It uses a format-preserving Feistel network with cycle walking, so it needs O(1) memory
+// whatever the size of the range, and any position can be computed directly with At.
+type Permutation struct {
+	n        uint64
+	halfBits uint
+	keys     [permutationRounds]uint64
+	next     uint64
+}
+
+// NewPermutation returns a random permutation of [0, n).
+func NewPermutation(n uint64) *Permutation {
+	return singleton.Permutation(n)
+}
+
+// Permutation returns a deterministic permutation of [0, n).
+// Iterating over it with Next yields every value of the range exactly once.
+func (c *Chaos) Permutation(n uint64) *Permutation {
+	p := &Permutation{n: n}
+	if n > 1 {
+		p.halfBits = uint(bits.Len64(n-1)+1) / 2
+	}
+	if p.halfBits == 0 {
+		p.halfBits = 1
+	}
+	r := c.rand()
+	for i := range p.keys {
+		p.keys[i] = r.Uint64()
+	}
+	return p
+}
+
+// Len returns the size of the permuted range.
+func (p *Permutation) Len() uint64 {
+	return p.n
+}
+
+// At returns the value at position i of the permutation.
+// It panics if i is out of the [0, n) range.
+func (p *Permutation) At(i uint64) uint64 {
+	if i >= p.n {
+		panic(fmt.Sprintf("chaos: permutation index out of range [%d] with length %d", i, p.n))
+	}
+	v := p.encrypt(i)
+	// The Feistel network permutes a power of 4 sized domain: walk the cycle until the value fits in the range.
+	for v >= p.n {
+		v = p.encrypt(v)
+	}
+	return v
+}
+
+// Next returns the value at the next position of the permutation.
+// Once every value was returned, it returns false.
+func (p *Permutation) Next() (uint64, bool) {
+	if p.next >= p.n {
+		return 0, false
+	}
+	v := p.At(p.next)
+	p.next++
+	return v, true
+}
+
+// Reset makes Next start over from the first position.
+func (p *Permutation) Reset() {
+	p.next = 0
+}
+
+func (p *Permutation) encrypt(v uint64) uint64 {
+	mask := uint64(1)<<p.halfBits - 1
+	left, right := v>>p.halfBits, v&mask
+	for _, key := range p.keys {
+		left, right = right, left^(mix64(right^key)&mask)
+	}
+	return left<<p.halfBits | right
+}
+
+// mix64 is the finalizer of the SplitMix64 generator.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
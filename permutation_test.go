@@ -0,0 +1,101 @@
+package chaos_test
+
+import (
+	"testing"
+
+	"github.com/raphoester/chaos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermutation(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		p1 := chaos.New(t.Name()).Permutation(1000)
+		p2 := chaos.New(t.Name()).Permutation(1000)
+		for i := uint64(0); i < 1000; i++ {
+			assert.Equal(t, p1.At(i), p2.At(i))
+		}
+	})
+
+	t.Run("yields every value exactly once", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for _, n := range []uint64{1, 2, 3, 7, 100, 1000, 4096, 12345} {
+			p := c.Permutation(n)
+			seen := make([]bool, n)
+			count := uint64(0)
+			for v, ok := p.Next(); ok; v, ok = p.Next() {
+				require.Less(t, v, n)
+				require.False(t, seen[v], "value %d returned twice for n=%d", v, n)
+				seen[v] = true
+				count++
+			}
+			assert.Equal(t, n, count)
+		}
+	})
+
+	t.Run("is not the identity", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := c.Permutation(1000)
+		moved := 0
+		for i := uint64(0); i < 1000; i++ {
+			if p.At(i) != i {
+				moved++
+			}
+		}
+		assert.Greater(t, moved, 900)
+	})
+
+	t.Run("different seeds produce different orders", func(t *testing.T) {
+		p1 := chaos.New("a").Permutation(1000)
+		p2 := chaos.New("b").Permutation(1000)
+		different := false
+		for i := uint64(0); i < 10; i++ {
+			if p1.At(i) != p2.At(i) {
+				different = true
+			}
+		}
+		assert.True(t, different)
+	})
+
+	t.Run("random access matches iteration", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := c.Permutation(500)
+		for i := uint64(0); i < 500; i++ {
+			v, ok := p.Next()
+			require.True(t, ok)
+			assert.Equal(t, p.At(i), v)
+		}
+		p.Reset()
+		v, ok := p.Next()
+		require.True(t, ok)
+		assert.Equal(t, p.At(0), v)
+	})
+
+	t.Run("handles huge ranges", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		const n = uint64(1) << 62
+		p := c.Permutation(n)
+		seen := make(map[uint64]bool)
+		for i := uint64(0); i < 10000; i++ {
+			v := p.At(i * 12345)
+			require.Less(t, v, n)
+			require.False(t, seen[v])
+			seen[v] = true
+		}
+	})
+
+	t.Run("edge case: empty range", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := c.Permutation(0)
+		_, ok := p.Next()
+		assert.False(t, ok)
+		assert.Panics(t, func() { p.At(0) })
+	})
+}
+
+func BenchmarkPermutation(b *testing.B) {
+	p := chaos.New(b.Name()).Permutation(1_000_000_000)
+	for i := 0; i < b.N; i++ {
+		p.At(uint64(i) % p.Len())
+	}
+}
@@ -0,0 +1,135 @@
+package chaos
+
+import (
+	"errors"
+	"math/rand"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrInvalidPattern = errors.New("invalid regular expression")
+)
+
+// regexMaxRepeat is the number of repetitions added to the minimum of unbounded repeats like * or +.
+const regexMaxRepeat = 10
+
+// printableASCII is the range of characters preferred by wide character classes, like . or [^a].
+var printableASCII = []rune{' ', '~'}
+
+// wideClassSize is the number of characters from which a character class is considered wide.
+const wideClassSize = 256
+
+// Regex returns a random string matching pattern.
+func Regex(pattern string) (string, error) {
+	return singleton.Regex(pattern)
+}
+
+// Regex returns a deterministic string matching pattern, in the RE2 syntax of the regexp package.
+// Behavior:
+//   - Unbounded repeats like * and + are repeated at most 10 times more than their minimum.
+//   - Wide character classes, like . or [^a], only produce printable ASCII characters
+//     whenever they contain some.
+//   - Anchors and word boundaries are ignored, so the whole string matches the pattern.
+//   - If the pattern is invalid, it returns an error.
+func (c *Chaos) Regex(pattern string) (string, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", errors.Join(ErrInvalidPattern, err)
+	}
+	var b strings.Builder
+	generateRegex(c.rand(), &b, re.Simplify())
+	return b.String(), nil
+}
+
+func generateRegex(r *rand.Rand, b *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, char := range re.Rune {
+			if re.Flags&syntax.FoldCase != 0 && r.Intn(2) == 0 {
+				char = unicode.SimpleFold(char)
+			}
+			b.WriteRune(char)
+		}
+	case syntax.OpCharClass:
+		if len(re.Rune) > 0 {
+			b.WriteRune(pickRune(r, re.Rune))
+		}
+	case syntax.OpAnyCharNotNL, syntax.OpAnyChar:
+		b.WriteRune(pickRune(r, printableASCII))
+	case syntax.OpCapture:
+		generateRegex(r, b, re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			generateRegex(r, b, sub)
+		}
+	case syntax.OpAlternate:
+		generateRegex(r, b, re.Sub[r.Intn(len(re.Sub))])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		min, max := repeatBounds(re)
+		n := min + r.Intn(max-min+1)
+		for i := 0; i < n; i++ {
+			generateRegex(r, b, re.Sub[0])
+		}
+	}
+}
+
+// repeatBounds returns the number of repetitions allowed by a repeat operator.
+func repeatBounds(re *syntax.Regexp) (int, int) {
+	switch re.Op {
+	case syntax.OpStar:
+		return 0, regexMaxRepeat
+	case syntax.OpPlus:
+		return 1, 1 + regexMaxRepeat
+	case syntax.OpQuest:
+		return 0, 1
+	default:
+		if re.Max < 0 {
+			return re.Min, re.Min + regexMaxRepeat
+		}
+		return re.Min, re.Max
+	}
+}
+
+// pickRune returns a rune of the class described by ranges, a list of inclusive [lo, hi] pairs.
+// Printable ASCII characters are preferred for wide classes containing some, as classes
+// like [^a] mostly contain unassigned or unprintable code points.
+func pickRune(r *rand.Rand, ranges []rune) rune {
+	total := classSize(ranges)
+	if total >= wideClassSize {
+		if ascii := intersectRanges(ranges, printableASCII); len(ascii) > 0 {
+			ranges = ascii
+			total = classSize(ascii)
+		}
+	}
+	pick := r.Intn(total)
+	for i := 0; i < len(ranges); i += 2 {
+		size := int(ranges[i+1]-ranges[i]) + 1
+		if pick < size {
+			return ranges[i] + rune(pick)
+		}
+		pick -= size
+	}
+	panic("unreachable")
+}
+
+func classSize(ranges []rune) int {
+	total := 0
+	for i := 0; i < len(ranges); i += 2 {
+		total += int(ranges[i+1]-ranges[i]) + 1
+	}
+	return total
+}
+
+// intersectRanges returns the intersection of the ranges with the single range bounds.
+func intersectRanges(ranges []rune, bounds []rune) []rune {
+	var ret []rune
+	for i := 0; i < len(ranges); i += 2 {
+		lo, hi := max(ranges[i], bounds[0]), min(ranges[i+1], bounds[1])
+		if lo <= hi {
+			ret = append(ret, lo, hi)
+		}
+	}
+	return ret
+}
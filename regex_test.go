@@ -0,0 +1,58 @@
+package chaos_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/raphoester/chaos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegex(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		r1, err1 := chaos.New(t.Name()).Regex(`[a-z]+@[a-z]{3,8}\.com`)
+		r2, err2 := chaos.New(t.Name()).Regex(`[a-z]+@[a-z]{3,8}\.com`)
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.Equal(t, r1, r2)
+	})
+
+	t.Run("matches the pattern", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		patterns := []string{
+			`^[A-Z]{2}-\d{4}$`,
+			`(foo|bar)+baz?`,
+			`[^a-z]{5}`,
+			`.{3,}`,
+			`(?i)hello`,
+			`\w+\s\w*`,
+			`[\p{Greek}]{2}`,
+			`a{0}b`,
+			`x*`,
+		}
+		for _, pattern := range patterns {
+			re := regexp.MustCompile(`^(?:` + pattern + `)$`)
+			for i := 0; i < 100; i++ {
+				s, err := c.Regex(pattern)
+				require.NoError(t, err)
+				assert.Regexp(t, re, s, "pattern %s", pattern)
+			}
+		}
+	})
+
+	t.Run("prefers printable characters for wide classes", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 100; i++ {
+			s, err := c.Regex(`[^0-9]{10}`)
+			require.NoError(t, err)
+			assert.Regexp(t, `^[ -/:-~]{10}$`, s)
+		}
+	})
+
+	t.Run("returns error for invalid patterns", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		_, err := c.Regex(`[a-`)
+		assert.ErrorIs(t, err, chaos.ErrInvalidPattern)
+	})
+}
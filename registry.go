@@ -0,0 +1,128 @@
+package chaos
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// ChaosGenerator is implemented by types generating their own random values,
+// like a Money type returning a positive amount in a known currency.
+// Fill and Arbitrary call Generate on the zero value of such types, or on a pointer
+// to it for methods with a pointer receiver.
+type ChaosGenerator[T any] interface {
+	Generate(c *Chaos) T
+}
+
+// registry holds the generators registered with Register, shared by every chaos.
+var registry = struct {
+	sync.RWMutex
+	generators map[reflect.Type]func(c *Chaos) reflect.Value
+}{
+	generators: make(map[reflect.Type]func(c *Chaos) reflect.Value),
+}
+
+// Register sets the generator of values of type T used by Fill and Arbitrary for every chaos.
+// It replaces any generator previously registered for T. It is meant to be called from
+// an init function or TestMain, before values are generated.
+//
+// When generating a value, Fill uses in order:
+//   - the value options of the struct tags of the field, like min, oneof, faker or email,
+//   - the generator registered for the chaos with RegisterFor,
+//   - the generator registered with Register,
+//   - the Generate method of types implementing ChaosGenerator,
+//   - the default generation of its kind.
+//
+// Options applying to the field itself, like nil, keep, unique or the length of slices,
+// are honored whatever the source of the value.
+func Register[T any](gen func(c *Chaos) T) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.generators[reflect.TypeFor[T]()] = reflectGenerator(gen)
+}
+
+// RegisterFor sets the generator of values of type T used by Fill and Arbitrary with c.
+// It takes precedence over the generator registered with Register, see Register for
+// the complete precedence rules. Chaos derived by c, like the ones given to generators,
+// share its registry.
+func RegisterFor[T any](c *Chaos, gen func(c *Chaos) T) {
+	if c.generators == nil {
+		c.generators = make(map[reflect.Type]func(c *Chaos) reflect.Value)
+	}
+	c.generators[reflect.TypeFor[T]()] = reflectGenerator(gen)
+}
+
+// Enum registers values as the only values of type T generated by Fill and Arbitrary,
+// like the constants of an enumeration, and returns a generator of them.
+// It panics if values is empty. The cmd/chaosgen tool generates the call from the constants of T.
+func Enum[T any](values ...T) Gen[T] {
+	if len(values) == 0 {
+		panic(fmt.Sprintf("chaos: enum %s without values", reflect.TypeFor[T]()))
+	}
+	g := Elements(slices.Clone(values)...)
+	Register(g.Generate)
+	return g
+}
+
+// Arbitrary returns a generator of values of type T, populated like Fill does.
+// It is the way to use registered generators and types implementing ChaosGenerator in combinators.
+// The generator panics if a struct tag of T is invalid.
+func Arbitrary[T any]() Gen[T] {
+	return GenFunc[T](func(c *Chaos) T {
+		var v T
+		if err := c.Fill(&v); err != nil {
+			panic(err)
+		}
+		return v
+	})
+}
+
+// reflectGenerator wraps gen in a generator of reflect values.
+// The values keep the static type T, so generators of interface types may return nil.
+func reflectGenerator[T any](gen func(c *Chaos) T) func(c *Chaos) reflect.Value {
+	return func(c *Chaos) reflect.Value {
+		v := gen(c)
+		return reflect.ValueOf(&v).Elem()
+	}
+}
+
+// generator returns the generator of values of type t, or nil if they are generated by default.
+func (c *Chaos) generator(t reflect.Type) func(c *Chaos) reflect.Value {
+	if gen, ok := c.generators[t]; ok {
+		return gen
+	}
+	registry.RLock()
+	gen, ok := registry.generators[t]
+	registry.RUnlock()
+	if ok {
+		return gen
+	}
+	return methodGenerator(t)
+}
+
+// methodGenerator returns the generator of values of type t implementing ChaosGenerator,
+// or nil if t does not implement it. Pointers are not generated by their methods,
+// as they would be called on nil: they are allocated and their element is generated instead.
+func methodGenerator(t reflect.Type) func(c *Chaos) reflect.Value {
+	if t.Kind() == reflect.Pointer {
+		return nil
+	}
+	chaosType := reflect.TypeFor[*Chaos]()
+	for _, receiver := range []reflect.Type{t, reflect.PointerTo(t)} {
+		m, ok := receiver.MethodByName("Generate")
+		if !ok || m.Type.NumIn() != 2 || m.Type.In(1) != chaosType ||
+			m.Type.NumOut() != 1 || m.Type.Out(0) != t {
+			continue
+		}
+		pointer := receiver != t
+		return func(c *Chaos) reflect.Value {
+			recv := reflect.New(t)
+			if !pointer {
+				recv = recv.Elem()
+			}
+			return recv.MethodByName("Generate").Call([]reflect.Value{reflect.ValueOf(c)})[0]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package chaos_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raphoester/chaos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tenantID string
+
+type money struct {
+	Amount   int64
+	Currency string
+}
+
+// Generate implements chaos.ChaosGenerator.
+func (money) Generate(c *chaos.Chaos) money {
+	return money{Amount: int64(c.IntBetween(1, 1000)), Currency: c.Currency().Code}
+}
+
+type status int
+
+// Generate implements chaos.ChaosGenerator with a pointer receiver.
+func (*status) Generate(c *chaos.Chaos) status {
+	return status(c.IntBetween(1, 3))
+}
+
+type invoice struct {
+	Tenant   tenantID
+	Total    money
+	Status   status
+	Lines    []money `chaos:"len=2"`
+	Previous *money
+	Override tenantID `chaos:"oneof=fixed"`
+	Label    tenantID `chaos:"len=4"`
+}
+
+func init() {
+	chaos.Register(func(c *chaos.Chaos) tenantID {
+		return tenantID(fmt.Sprintf("tenant-%d", c.IntBetween(1, 9)))
+	})
+}
+
+func TestRegister(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		var i1, i2 invoice
+		require.NoError(t, chaos.New(t.Name()).Fill(&i1))
+		require.NoError(t, chaos.New(t.Name()).Fill(&i2))
+		assert.Equal(t, i1, i2)
+	})
+
+	t.Run("uses registered generators and ChaosGenerator", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 100; i++ {
+			var inv invoice
+			require.NoError(t, c.Fill(&inv))
+			assert.Regexp(t, `^tenant-[1-9]$`, inv.Tenant)
+			assert.GreaterOrEqual(t, inv.Total.Amount, int64(1))
+			assert.LessOrEqual(t, inv.Total.Amount, int64(1000))
+			assert.Len(t, inv.Total.Currency, 3)
+			assert.GreaterOrEqual(t, inv.Status, status(1))
+			assert.LessOrEqual(t, inv.Status, status(3))
+			require.Len(t, inv.Lines, 2)
+			for _, line := range inv.Lines {
+				assert.Len(t, line.Currency, 3)
+			}
+			require.NotNil(t, inv.Previous)
+			assert.Len(t, inv.Previous.Currency, 3)
+		}
+	})
+
+	t.Run("tags take precedence", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		var inv invoice
+		require.NoError(t, c.Fill(&inv))
+		assert.Equal(t, tenantID("fixed"), inv.Override)
+		assert.Len(t, inv.Label, 4)
+		assert.False(t, strings.HasPrefix(string(inv.Label), "tenant-"))
+	})
+
+	t.Run("instance generators take precedence", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		chaos.RegisterFor(c, func(c *chaos.Chaos) tenantID {
+			return "local"
+		})
+		chaos.RegisterFor(c, func(c *chaos.Chaos) money {
+			return money{Amount: -1}
+		})
+		var inv invoice
+		require.NoError(t, c.Fill(&inv))
+		assert.Equal(t, tenantID("local"), inv.Tenant)
+		assert.Equal(t, int64(-1), inv.Total.Amount)
+
+		var other invoice
+		require.NoError(t, chaos.New(t.Name()).Fill(&other))
+		assert.NotEqual(t, tenantID("local"), other.Tenant)
+	})
+
+	t.Run("generators share the instance registry", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		chaos.RegisterFor(c, func(c *chaos.Chaos) tenantID {
+			return "local"
+		})
+		chaos.RegisterFor(c, func(c *chaos.Chaos) money {
+			return money{Currency: string(chaos.Arbitrary[tenantID]().Generate(c))}
+		})
+		var inv invoice
+		require.NoError(t, c.Fill(&inv))
+		assert.Equal(t, "local", inv.Total.Currency)
+	})
+
+	t.Run("unique registered values", func(t *testing.T) {
+		type user struct {
+			Tenant tenantID `chaos:"unique"`
+		}
+		c := chaos.New(t.Name())
+		seen := make(map[tenantID]bool)
+		for i := 0; i < 9; i++ {
+			var u user
+			require.NoError(t, c.Fill(&u))
+			require.False(t, seen[u.Tenant], "tenant %s issued twice", u.Tenant)
+			seen[u.Tenant] = true
+		}
+		var u user
+		assert.ErrorIs(t, c.Fill(&u), chaos.ErrUniqueExhausted)
+	})
+
+	t.Run("interfaces", func(t *testing.T) {
+		type holder struct{ Value fmt.Stringer }
+		c := chaos.New(t.Name())
+		var h holder
+		require.NoError(t, c.Fill(&h))
+		assert.Nil(t, h.Value)
+
+		chaos.RegisterFor(c, func(c *chaos.Chaos) fmt.Stringer {
+			return c.Duration(time.Hour)
+		})
+		require.NoError(t, c.Fill(&h))
+		assert.IsType(t, time.Duration(0), h.Value)
+	})
+}
+
+func TestArbitrary(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		g := chaos.SliceOf(chaos.Arbitrary[invoice](), 1, 3)
+		assert.Equal(t, g.Generate(chaos.New(t.Name())), g.Generate(chaos.New(t.Name())))
+	})
+
+	t.Run("uses registered generators", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 100; i++ {
+			assert.Regexp(t, `^tenant-[1-9]$`, chaos.Arbitrary[tenantID]().Generate(c))
+			assert.Len(t, chaos.Arbitrary[money]().Generate(c).Currency, 3)
+		}
+	})
+}
+
+type color string
+
+func TestEnum(t *testing.T) {
+	colors := chaos.Enum[color]("red", "green", "blue")
+
+	t.Run("deterministic output", func(t *testing.T) {
+		assert.Equal(t, colors.Generate(chaos.New(t.Name())), colors.Generate(chaos.New(t.Name())))
+	})
+
+	t.Run("fill generates enum values", func(t *testing.T) {
+		type car struct {
+			Color   color
+			Colors  []color
+			Painted color `chaos:"oneof=black"`
+		}
+		c := chaos.New(t.Name())
+		for i := 0; i < 100; i++ {
+			var cr car
+			require.NoError(t, c.Fill(&cr))
+			assert.Contains(t, []color{"red", "green", "blue"}, cr.Color)
+			for _, col := range cr.Colors {
+				assert.Contains(t, []color{"red", "green", "blue"}, col)
+			}
+			assert.Equal(t, color("black"), cr.Painted)
+			assert.Contains(t, []color{"red", "green", "blue"}, colors.Generate(c))
+		}
+	})
+
+	t.Run("panics without values", func(t *testing.T) {
+		assert.Panics(t, func() { chaos.Enum[color]() })
+	})
+}
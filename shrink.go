@@ -0,0 +1,229 @@
+package chaos
+
+import (
+	"math"
+	"slices"
+)
+
+// defaultShrinks is the number of runs spent shrinking a failing case, unless changed by Shrinks.
+const defaultShrinks = 1000
+
+// In a case run by Check, every draw of the chaos is a choice: a number recorded by the run.
+// Replaying the choices replays the case, and smaller choices make simpler values: integers
+// closer to zero, shorter slices and strings, and items picked closer to the start of slices.
+// The shrinker searches for the simplest choices for which the property still fails, like the
+// choice sequences of Hypothesis, so generators do not need to know how to shrink their values.
+
+// choose returns a choice between 0 and n.
+// When the run replays choices, it returns the next one, capped to n, or 0 past the last one.
+func (c *Chaos) choose(n uint64) uint64 {
+	r := c.run
+	var v uint64
+	if r.replay {
+		if i := len(r.choices); i < len(r.prefix) {
+			v = min(r.prefix[i], n)
+		}
+	} else {
+		v = c.uniform(n)
+	}
+	r.choices = append(r.choices, v)
+	return v
+}
+
+// uniform returns a uniform number between 0 and n, drawn from the seed and the count of c.
+func (c *Chaos) uniform(n uint64) uint64 {
+	r := c.seededRand()
+	if n < math.MaxInt64 {
+		return uint64(r.Int63n(int64(n) + 1))
+	}
+	v := r.Uint64()
+	if n != math.MaxUint64 {
+		v %= n + 1
+	}
+	return v
+}
+
+// chooseNearZero returns a value between lo < 0 and hi > 0, uniformly distributed, recorded as
+// two choices: its magnitude, then its sign. Smaller choices are then closer to zero, and positive.
+func (c *Chaos) chooseNearZero(lo, hi int64) int64 {
+	r := c.run
+	below, above := uint64(-lo), uint64(hi)
+	if !r.replay {
+		v := int64(uint64(lo) + c.uniform(above+below))
+		if v < 0 {
+			r.choices = append(r.choices, uint64(-v), 1)
+		} else {
+			r.choices = append(r.choices, uint64(v), 0)
+		}
+		return v
+	}
+
+	magnitude := c.choose(max(below, above))
+	negative := c.choose(1) == 1
+	if negative && magnitude <= below || magnitude > above {
+		return -int64(magnitude)
+	}
+	return int64(magnitude)
+}
+
+// list records the choices of the items of a slice, so the shrinker can remove items.
+type list struct {
+	// length is the index of the choice of the length, or -1 if the length was not drawn.
+	length int
+	// items are the start and end indices of the choices of each item.
+	items [][2]int
+}
+
+// without returns choices without the choices of size items of l from start, and with
+// a length reduced accordingly.
+func (l list) without(choices []uint64, start, size int) []uint64 {
+	from, to := l.items[start][0], l.items[start+size-1][1]
+	ret := slices.Concat(choices[:from], choices[to:])
+	if l.length >= 0 {
+		ret[l.length] -= min(ret[l.length], uint64(size))
+	}
+	return ret
+}
+
+// repeat calls item a number of times drawn between minLen and maxLen.
+// In a case run by Check, it records the choices of the items, so the shrinker can remove them.
+func (c *Chaos) repeat(minLen, maxLen int, item func()) {
+	r := c.run
+	if r == nil {
+		n := max(c.IntBetween(minLen, maxLen), 0)
+		for i := 0; i < n; i++ {
+			item()
+		}
+		return
+	}
+
+	l := len(r.lists)
+	r.lists = append(r.lists, list{length: len(r.choices)})
+	n := max(c.IntBetween(minLen, maxLen), 0)
+	if len(r.choices) == r.lists[l].length {
+		r.lists[l].length = -1
+	}
+	for i := 0; i < n; i++ {
+		start := len(r.choices)
+		item()
+		r.lists[l].items = append(r.lists[l].items, [2]int{start, len(r.choices)})
+	}
+}
+
+// shrinker searches for the simplest choices for which a property fails.
+type shrinker struct {
+	property func(c *Chaos) bool
+	seed     string
+	// best is the simplest failing run found so far.
+	best *propertyRun
+	// budget is the number of runs left.
+	budget int
+}
+
+// shrink simplifies the best run until no pass improves it or the budget is spent.
+// Passes are ordered from the cheapest to the most expensive, and the shrinker starts over
+// from the first one whenever a pass improves the run.
+func (s *shrinker) shrink() {
+	for s.budget > 0 && (s.removeItems() || s.minimizeChoices() || s.zeroBlocks() || s.deleteBlocks()) {
+	}
+}
+
+// try replays choices, and keeps the run if the property fails with simpler choices than the best run.
+func (s *shrinker) try(choices []uint64) bool {
+	if s.budget <= 0 {
+		return false
+	}
+	s.budget--
+	run := &propertyRun{prefix: choices, replay: true}
+	c := New(s.seed)
+	c.run = run
+	if run.check(c, s.property) || !simpler(run.choices, s.best.choices) {
+		return false
+	}
+	s.best = run
+	return true
+}
+
+// simpler reports whether a is simpler than b: shorter, or lexicographically smaller.
+func simpler(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return slices.Compare(a, b) < 0
+}
+
+// removeItems removes items of slices, by chunks of decreasing sizes.
+func (s *shrinker) removeItems() bool {
+	improved := false
+	for i := 0; i < len(s.best.lists); i++ {
+		for size := len(s.best.lists[i].items); size > 0; size /= 2 {
+			for start := 0; s.budget > 0 && i < len(s.best.lists) && start+size <= len(s.best.lists[i].items); {
+				if s.try(s.best.lists[i].without(s.best.choices, start, size)) {
+					improved = true
+				} else {
+					start += size
+				}
+			}
+		}
+	}
+	return improved
+}
+
+// deleteBlocks deletes blocks of consecutive choices, from the end.
+func (s *shrinker) deleteBlocks() bool {
+	improved := false
+	for _, size := range []int{8, 4, 2, 1} {
+		for i := len(s.best.choices) - size; s.budget > 0 && i >= 0; i-- {
+			if i+size <= len(s.best.choices) {
+				improved = s.try(slices.Delete(slices.Clone(s.best.choices), i, i+size)) || improved
+			}
+		}
+	}
+	return improved
+}
+
+// zeroBlocks sets blocks of consecutive choices to zero.
+func (s *shrinker) zeroBlocks() bool {
+	improved := false
+	for _, size := range []int{8, 4, 2} {
+		for i := 0; s.budget > 0 && i+size <= len(s.best.choices); i++ {
+			block := s.best.choices[i : i+size]
+			if slices.ContainsFunc(block, func(v uint64) bool { return v != 0 }) {
+				candidate := slices.Clone(s.best.choices)
+				clear(candidate[i : i+size])
+				improved = s.try(candidate) || improved
+			}
+		}
+	}
+	return improved
+}
+
+// minimizeChoices replaces each choice with the smallest one for which the property fails,
+// found by binary search.
+func (s *shrinker) minimizeChoices() bool {
+	improved := false
+	replace := func(i int, v uint64) bool {
+		candidate := slices.Clone(s.best.choices)
+		candidate[i] = v
+		return s.try(candidate)
+	}
+	for i := 0; s.budget > 0 && i < len(s.best.choices); i++ {
+		if s.best.choices[i] == 0 {
+			continue
+		}
+		if replace(i, 0) {
+			improved = true
+			continue
+		}
+		lo := uint64(0)
+		for s.budget > 0 && i < len(s.best.choices) && lo+1 < s.best.choices[i] {
+			mid := lo + (s.best.choices[i]-lo)/2
+			if replace(i, mid) {
+				improved = true
+			} else {
+				lo = mid
+			}
+		}
+	}
+	return improved
+}
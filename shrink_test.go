@@ -0,0 +1,107 @@
+package chaos_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/raphoester/chaos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failure returns the report of the failure of property.
+func failure(t *testing.T, property func(c *chaos.Chaos) bool, options ...chaos.CheckOption) string {
+	t.Helper()
+	r := &recorder{name: t.Name()}
+	require.False(t, chaos.Check(r, property, options...))
+	require.Len(t, r.errors, 1)
+	return r.errors[0]
+}
+
+func TestShrink(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		property := func(c *chaos.Chaos) bool {
+			return len(chaos.Input(c, "s", chaos.StringOf("abc", 0, 50))) < 10
+		}
+		assert.Equal(t, failure(t, property), failure(t, property))
+	})
+
+	t.Run("moves integers towards zero", func(t *testing.T) {
+		report := failure(t, func(c *chaos.Chaos) bool {
+			return chaos.Input(c, "x", chaos.IntGen(-1_000_000, 1_000_000)) < 500
+		})
+		assert.Contains(t, report, "\n\tx: 500\n")
+
+		report = failure(t, func(c *chaos.Chaos) bool {
+			return chaos.Input(c, "x", chaos.Int64Gen(-1_000_000, 1_000_000)) > -500
+		})
+		assert.Contains(t, report, "\n\tx: -500\n")
+	})
+
+	t.Run("removes slice items", func(t *testing.T) {
+		report := failure(t, func(c *chaos.Chaos) bool {
+			xs := chaos.Input(c, "xs", chaos.SliceOf(chaos.IntGen(0, 1000), 0, 300))
+			return !slices.ContainsFunc(xs, func(x int) bool { return x >= 100 })
+		})
+		assert.Contains(t, report, "\n\txs: []int{100}\n")
+	})
+
+	t.Run("shortens strings", func(t *testing.T) {
+		report := failure(t, func(c *chaos.Chaos) bool {
+			return len(chaos.Input(c, "s", chaos.StringOf("abcdefghijklmnopqrstuvwxyz", 0, 100))) < 5
+		})
+		assert.Contains(t, report, "\n\ts: \"aaaaa\"\n")
+	})
+
+	t.Run("picks unique items closer to the start", func(t *testing.T) {
+		items := make([]int, 100)
+		for i := range items {
+			items[i] = i
+		}
+		report := failure(t, func(c *chaos.Chaos) bool {
+			picked := chaos.Input(c, "picked", chaos.Const(chaos.NewSliceProcessor[[]int](c).MustUniqueItems(items, 3)))
+			return !slices.ContainsFunc(picked, func(i int) bool { return i >= 50 })
+		})
+		assert.Contains(t, report, "\n\tpicked: []int{0, 1, 50}\n")
+	})
+
+	t.Run("shrinks filled values", func(t *testing.T) {
+		type user struct {
+			Tags []string `chaos:"minlen=1"`
+			Age  int
+		}
+		report := failure(t, func(c *chaos.Chaos) bool {
+			var u user
+			require.NoError(t, c.Fill(&u))
+			return chaos.Input(c, "user", chaos.Const(u)).Age < 1000
+		})
+		assert.Contains(t, report, `Tags:[]string{"aaaaaaaaaa"}, Age:1000}`)
+	})
+
+	t.Run("reports panics of the shrunk case", func(t *testing.T) {
+		report := failure(t, func(c *chaos.Chaos) bool {
+			xs := chaos.Input(c, "xs", chaos.SliceOf(chaos.IntGen(0, 100), 0, 50))
+			return xs[len(xs)-1] >= 0
+		})
+		assert.Contains(t, report, "panic: runtime error: index out of range [-1]")
+		assert.Contains(t, report, "\n\txs: []int{}\n")
+	})
+
+	t.Run("respects the budget", func(t *testing.T) {
+		runs := 0
+		report := failure(t, func(c *chaos.Chaos) bool {
+			runs++
+			return len(chaos.Input(c, "xs", chaos.SliceOf(chaos.IntGen(0, 100), 0, 100))) < 5
+		}, chaos.Shrinks(10))
+		assert.Contains(t, report, "shrunk in 10 runs")
+		assert.LessOrEqual(t, runs, 100+10)
+	})
+
+	t.Run("no shrinking", func(t *testing.T) {
+		report := failure(t, func(c *chaos.Chaos) bool {
+			return chaos.Input(c, "x", chaos.IntGen(0, 1000)) < 500
+		}, chaos.Shrinks(0))
+		assert.NotContains(t, report, "shrunk")
+		assert.NotContains(t, report, "\n\tx: 500\n")
+	})
+}
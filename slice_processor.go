@@ -3,6 +3,8 @@ package chaos
 import (
 	"errors"
 	"fmt"
+	"math/rand"
+	"sort"
 )
 
 // NewSliceProcessor returns a new SliceProcessor.
@@ -24,7 +26,7 @@ func SliceItem[S ~[]T, T any](items S) T {
 // Item returns a random item from the slice.
 func (s *SliceProcessor[S, T]) Item(items S) T {
 	var ret T
-	if len(items)== 0 {
+	if len(items) == 0 {
 		return ret
 	}
 
@@ -44,22 +46,160 @@ func UniqueSliceItems[S ~[]T, T any](items S, count int) (S, error) {
 // The items are unique.
 // If there are not enough items to select from, it returns an error.
 func (s *SliceProcessor[S, T]) UniqueItems(items S, count int) (S, error) {
-	if len(items) < count {
-		return nil, errors.Join(ErrNotEnoughItemsInSlice,
-			fmt.Errorf("not enough items to select from: %d < %d", len(items), count))
+	indices, err := s.c.SampleIndices(len(items), count)
+	if err != nil {
+		return nil, err
+	}
+
+	selectedItems := make(S, 0, len(indices))
+	for _, index := range indices {
+		selectedItems = append(selectedItems, items[index])
+	}
+
+	return selectedItems, nil
+}
+
+// UniqueSliceItemsBy returns random items from the slice with distinct keys.
+func UniqueSliceItemsBy[S ~[]T, T any, K comparable](items S, count int, key func(T) K) (S, error) {
+	return UniqueBy(singleton, items, count, key)
+}
+
+// UniqueBy returns a slice with a length of count, with items picked by c.
+// The items have distinct keys, as computed by the key function.
+// It is a function rather than a method of SliceProcessor, as methods cannot declare the type of the keys.
+// If there are not enough distinct keys to select from, it returns an error.
+func UniqueBy[S ~[]T, T any, K comparable](c *Chaos, items S, count int, key func(T) K) (S, error) {
+	if count <= 0 {
+		return S{}, nil
 	}
 
 	selectedItems := make(S, 0, count)
-	availableItems := append(S(nil), items...)
-	for i := 0; i < count; i++ {
-		index := s.c.Int(len(availableItems) - 1)
-		selectedItems = append(selectedItems, availableItems[index])
-		availableItems = append(availableItems[:index], availableItems[index+1:]...)
+	seen := make(map[K]struct{}, count)
+	sampler := c.indexSampler(len(items))
+	for len(selectedItems) < count {
+		index, ok := sampler.next()
+		if !ok {
+			return nil, errors.Join(ErrNotEnoughItemsInSlice,
+				fmt.Errorf("not enough distinct values to select from: %d < %d", len(seen), count))
+		}
+		k := key(items[index])
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		selectedItems = append(selectedItems, items[index])
 	}
 
 	return selectedItems, nil
 }
 
+// NewComparableSliceProcessor returns a new ComparableSliceProcessor.
+func NewComparableSliceProcessor[S ~[]T, T comparable](c *Chaos) *ComparableSliceProcessor[S, T] {
+	return &ComparableSliceProcessor[S, T]{
+		SliceProcessor: NewSliceProcessor[S, T](c),
+	}
+}
+
+// ComparableSliceProcessor is a SliceProcessor for slices of comparable items.
+// It adds helpers relying on the equality of items.
+type ComparableSliceProcessor[S ~[]T, T comparable] struct {
+	*SliceProcessor[S, T]
+}
+
+// UniqueSliceValues returns random distinct values from the slice.
+func UniqueSliceValues[S ~[]T, T comparable](items S, count int) (S, error) {
+	return NewComparableSliceProcessor[S, T](singleton).UniqueValues(items, count)
+}
+
+// UniqueValues returns a slice with a length of count.
+// Unlike UniqueItems, which only guarantees distinct positions, the values are distinct.
+// If there are not enough distinct values to select from, it returns an error.
+func (s *ComparableSliceProcessor[S, T]) UniqueValues(items S, count int) (S, error) {
+	return UniqueBy(s.c, items, count, func(item T) T {
+		return item
+	})
+}
+
+// indexSampler draws distinct indices in [0, n), in random order.
+// It runs a partial Fisher–Yates shuffle over a virtual slice of indices, only recording
+// the swapped positions, so drawing k indices costs O(k) time and memory whatever n is.
+type indexSampler struct {
+	intn  func(n int) int
+	n     int
+	drawn int
+	swaps map[int]int
+}
+
+func newIndexSampler(r *rand.Rand, n int) *indexSampler {
+	return &indexSampler{
+		intn:  r.Intn,
+		n:     n,
+		swaps: make(map[int]int),
+	}
+}
+
+// indexSampler returns a sampler of indices in [0, n) drawn from c.
+// In a case run by Check, each index is a choice, so shrinking picks the first indices.
+func (c *Chaos) indexSampler(n int) *indexSampler {
+	if c.run == nil {
+		return newIndexSampler(c.rand(), n)
+	}
+	return &indexSampler{
+		intn:  func(n int) int { return c.Int(n - 1) },
+		n:     n,
+		swaps: make(map[int]int),
+	}
+}
+
+func (s *indexSampler) at(position int) int {
+	if index, ok := s.swaps[position]; ok {
+		return index
+	}
+	return position
+}
+
+// next returns the next index, or false once every index was drawn.
+func (s *indexSampler) next() (int, bool) {
+	if s.drawn >= s.n {
+		return 0, false
+	}
+	position := s.drawn + s.intn(s.n-s.drawn)
+	index := s.at(position)
+	s.swaps[position] = s.at(s.drawn)
+	delete(s.swaps, s.drawn)
+	s.drawn++
+	return index, true
+}
+
+// SampleIndices returns k distinct random indices between 0 and n (exclusive).
+func SampleIndices(n, k int) ([]int, error) {
+	return singleton.SampleIndices(n, k)
+}
+
+// SampleIndices returns k distinct deterministic indices between 0 and n (exclusive), in random order.
+// Behavior:
+//   - It draws a single random source, or one choice per index in Check, and runs in O(k) time
+//     and memory whatever n is.
+//   - If k <= 0, it returns an empty slice.
+//   - If k > n, it returns an error.
+func (c *Chaos) SampleIndices(n, k int) ([]int, error) {
+	if k > n {
+		return nil, errors.Join(ErrNotEnoughItemsInSlice,
+			fmt.Errorf("not enough items to select from: %d < %d", n, k))
+	}
+	if k <= 0 {
+		return []int{}, nil
+	}
+
+	sampler := c.indexSampler(n)
+	indices := make([]int, 0, k)
+	for i := 0; i < k; i++ {
+		index, _ := sampler.next()
+		indices = append(indices, index)
+	}
+	return indices, nil
+}
+
 // MustUniqueSliceItems returns a random item from the slice.
 func MustUniqueSliceItems[S ~[]T, T any](items S, count int) S {
 	return NewSliceProcessor[S, T](singleton).MustUniqueItems(items, count)
@@ -75,3 +215,169 @@ func (s *SliceProcessor[S, T]) MustUniqueItems(items S, count int) S {
 	}
 	return selectedItems
 }
+
+// ShuffleSlice shuffles the slice in place.
+func ShuffleSlice[S ~[]T, T any](items S) {
+	NewSliceProcessor[S, T](singleton).Shuffle(items)
+}
+
+// Shuffle shuffles the slice in place.
+func (s *SliceProcessor[S, T]) Shuffle(items S) {
+	s.c.rand().Shuffle(len(items), func(i, j int) {
+		items[i], items[j] = items[j], items[i]
+	})
+}
+
+// ShuffledSlice returns a shuffled copy of the slice.
+func ShuffledSlice[S ~[]T, T any](items S) S {
+	return NewSliceProcessor[S, T](singleton).Shuffled(items)
+}
+
+// Shuffled returns a shuffled copy of the slice.
+// The original slice is left untouched.
+func (s *SliceProcessor[S, T]) Shuffled(items S) S {
+	shuffled := append(S{}, items...)
+	s.Shuffle(shuffled)
+	return shuffled
+}
+
+// SampleSlice returns k random items from the slice, with replacement.
+func SampleSlice[S ~[]T, T any](items S, k int) S {
+	return NewSliceProcessor[S, T](singleton).Sample(items, k)
+}
+
+// Sample returns a slice of k items picked from the slice, with replacement.
+// The same item can be picked several times.
+// If the slice is empty or k <= 0, it returns an empty slice.
+func (s *SliceProcessor[S, T]) Sample(items S, k int) S {
+	if len(items) == 0 || k <= 0 {
+		return S{}
+	}
+	r := s.c.rand()
+	sample := make(S, k)
+	for i := range sample {
+		sample[i] = items[r.Intn(len(items))]
+	}
+	return sample
+}
+
+// SliceSubset returns a random subset of the slice.
+func SliceSubset[S ~[]T, T any](items S) S {
+	return NewSliceProcessor[S, T](singleton).Subset(items)
+}
+
+// Subset returns a subset of the slice, of random size between 0 and len(items) (inclusive).
+// The items keep their original order.
+func (s *SliceProcessor[S, T]) Subset(items S) S {
+	r := s.c.rand()
+	size := r.Intn(len(items) + 1)
+	sampler := newIndexSampler(r, len(items))
+	indices := make([]int, size)
+	for i := range indices {
+		indices[i], _ = sampler.next()
+	}
+	sort.Ints(indices)
+
+	subset := make(S, 0, size)
+	for _, index := range indices {
+		subset = append(subset, items[index])
+	}
+	return subset
+}
+
+// PartitionSlice splits the slice into n random groups.
+func PartitionSlice[S ~[]T, T any](items S, n int) []S {
+	return NewSliceProcessor[S, T](singleton).Partition(items, n)
+}
+
+// Partition splits the items into n groups of random sizes.
+// Every item ends up in exactly one group, and groups may be empty.
+// If n <= 0, it returns nil.
+func (s *SliceProcessor[S, T]) Partition(items S, n int) []S {
+	if n <= 0 {
+		return nil
+	}
+	r := s.c.rand()
+	shuffled := append(S{}, items...)
+	r.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	cuts := make([]int, n-1)
+	for i := range cuts {
+		cuts[i] = r.Intn(len(shuffled) + 1)
+	}
+	sort.Ints(cuts)
+
+	groups := make([]S, 0, n)
+	start := 0
+	for _, cut := range append(cuts, len(shuffled)) {
+		groups = append(groups, shuffled[start:cut:cut])
+		start = cut
+	}
+	return groups
+}
+
+// maxRankedPermutationItems is the largest number of items whose permutations can be ranked in a uint64 (20! < 2^64).
+const maxRankedPermutationItems = 20
+
+// SlicePermutations returns count distinct random permutations of the slice.
+func SlicePermutations[S ~[]T, T any](items S, count int) ([]S, error) {
+	return NewSliceProcessor[S, T](singleton).Permutations(items, count)
+}
+
+// Permutations returns count distinct permutations of the slice.
+// Permutations are distinct in terms of positions: with duplicated items, two of them can hold the same values.
+// Behavior:
+//   - If count exceeds the number of possible permutations, it returns an error.
+//   - Up to 20 items, distinct permutations are picked among all the possible ones.
+//   - Above that, permutations are shuffles, which are distinct with overwhelming probability.
+func (s *SliceProcessor[S, T]) Permutations(items S, count int) ([]S, error) {
+	if count <= 0 {
+		return []S{}, nil
+	}
+	if len(items) > maxRankedPermutationItems {
+		permutations := make([]S, count)
+		for i := range permutations {
+			permutations[i] = s.Shuffled(items)
+		}
+		return permutations, nil
+	}
+
+	total := uint64(1)
+	for i := 2; i <= len(items); i++ {
+		total *= uint64(i)
+	}
+	if uint64(count) > total {
+		return nil, errors.Join(ErrNotEnoughItemsInSlice,
+			fmt.Errorf("not enough permutations to select from: %d < %d", total, count))
+	}
+
+	ranks := s.c.Permutation(total)
+	permutations := make([]S, count)
+	for i := range permutations {
+		rank, _ := ranks.Next()
+		permutations[i] = unrankPermutation(items, rank)
+	}
+	return permutations, nil
+}
+
+// unrankPermutation returns the permutation of items with the given lexicographic rank.
+func unrankPermutation[S ~[]T, T any](items S, rank uint64) S {
+	remaining := append(S{}, items...)
+	permutation := make(S, 0, len(items))
+	factorial := uint64(1)
+	for i := 2; i < len(items); i++ {
+		factorial *= uint64(i)
+	}
+	for len(remaining) > 0 {
+		index := rank / factorial
+		rank %= factorial
+		permutation = append(permutation, remaining[index])
+		remaining = append(remaining[:index], remaining[index+1:]...)
+		if len(remaining) > 1 {
+			factorial /= uint64(len(remaining))
+		}
+	}
+	return permutation
+}
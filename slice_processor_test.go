@@ -174,3 +174,335 @@ func uniqueInts(slice []int) map[int]struct{} {
 	}
 	return unique
 }
+
+func TestSampleIndices(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		result1, err1 := c.SampleIndices(100, 10)
+		result2, err2 := c.SampleIndices(100, 10)
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.Equal(t, result1, result2)
+	})
+
+	t.Run("returns distinct indices within range", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 100; i++ {
+			result, err := c.SampleIndices(50, 20)
+			require.NoError(t, err)
+			assert.Len(t, result, 20)
+			assert.Len(t, uniqueInts(result), 20)
+			for _, index := range result {
+				assert.GreaterOrEqual(t, index, 0)
+				assert.Less(t, index, 50)
+			}
+		}
+	})
+
+	t.Run("selects all indices with approximately equal probability", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		counts := make(map[int]int)
+		const iterations = 10000
+		for i := 0; i < iterations; i++ {
+			result, err := c.SampleIndices(10, 3)
+			require.NoError(t, err)
+			for _, index := range result {
+				counts[index]++
+			}
+		}
+		expectedCount := float64(iterations*3) / 10
+		for index, count := range counts {
+			assert.InDelta(t, expectedCount, count, expectedCount*0.1, "index %d", index)
+		}
+	})
+
+	t.Run("scales to huge ranges", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		result, err := c.SampleIndices(1<<40, 1000)
+		require.NoError(t, err)
+		assert.Len(t, uniqueInts(result), 1000)
+	})
+
+	t.Run("returns error when not enough items", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		_, err := c.SampleIndices(3, 4)
+		assert.ErrorIs(t, err, chaos.ErrNotEnoughItemsInSlice)
+	})
+
+	t.Run("edge case: zero indices", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		result, err := c.SampleIndices(10, 0)
+		require.NoError(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func BenchmarkUniqueItems(b *testing.B) {
+	for _, size := range []int{1_000, 1_000_000} {
+		items := make([]int, size)
+		for i := range items {
+			items[i] = i
+		}
+		for _, count := range []int{10, 1_000} {
+			b.Run(fmt.Sprintf("len=%d/count=%d", size, count), func(b *testing.B) {
+				p := chaos.NewSliceProcessor[[]int](chaos.New(b.Name()))
+				for i := 0; i < b.N; i++ {
+					if _, err := p.UniqueItems(items, count); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkSampleIndices(b *testing.B) {
+	for _, n := range []int{1_000_000, 1_000_000_000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			c := chaos.New(b.Name())
+			for i := 0; i < b.N; i++ {
+				if _, err := c.SampleIndices(n, 1_000); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestUniqueSliceValues(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		p := chaos.NewComparableSliceProcessor[[]string](c)
+
+		items := []string{"a", "a", "b", "c", "c"}
+		result1, err1 := p.UniqueValues(items, 2)
+		result2, err2 := p.UniqueValues(items, 2)
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.Equal(t, result1, result2)
+	})
+
+	t.Run("returns distinct values", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewComparableSliceProcessor[[]string](c)
+
+		items := []string{"a", "a", "a", "a", "b"}
+		for i := 0; i < 100; i++ {
+			result, err := p.UniqueValues(items, 2)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []string{"a", "b"}, result)
+		}
+	})
+
+	t.Run("returns error when not enough distinct values", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewComparableSliceProcessor[[]string](c)
+
+		_, err := p.UniqueValues([]string{"a", "a", "b"}, 3)
+		assert.ErrorIs(t, err, chaos.ErrNotEnoughItemsInSlice)
+	})
+
+	t.Run("keeps the SliceProcessor helpers", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewComparableSliceProcessor[[]int](c)
+		assert.Contains(t, []int{1, 2, 3}, p.Item([]int{1, 2, 3}))
+	})
+}
+
+func TestUniqueSliceItemsBy(t *testing.T) {
+	type user struct {
+		Name  string
+		Email string
+	}
+	users := []user{
+		{Name: "John", Email: "john@example.com"},
+		{Name: "Johnny", Email: "john@example.com"},
+		{Name: "Jane", Email: "jane@example.com"},
+	}
+	byEmail := func(u user) string { return u.Email }
+
+	t.Run("returns items with distinct keys", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 100; i++ {
+			result, err := chaos.UniqueBy(c, users, 2, byEmail)
+			require.NoError(t, err)
+			require.Len(t, result, 2)
+			assert.NotEqual(t, result[0].Email, result[1].Email)
+		}
+	})
+
+	t.Run("returns error when not enough distinct keys", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		_, err := chaos.UniqueBy(c, users, 3, byEmail)
+		assert.ErrorIs(t, err, chaos.ErrNotEnoughItemsInSlice)
+	})
+
+	t.Run("edge case: zero items", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		result, err := chaos.UniqueBy(c, users, 0, byEmail)
+		require.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("singleton", func(t *testing.T) {
+		result, err := chaos.UniqueSliceItemsBy(users, 2, byEmail)
+		require.NoError(t, err)
+		assert.Len(t, result, 2)
+	})
+}
+
+func TestShuffle(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		p := chaos.NewSliceProcessor[[]int](c)
+		items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+		assert.Equal(t, p.Shuffled(items), p.Shuffled(items))
+	})
+
+	t.Run("shuffles in place", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewSliceProcessor[[]int](c)
+		items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+		p.Shuffle(items)
+		assert.ElementsMatch(t, []int{1, 2, 3, 4, 5, 6, 7, 8}, items)
+		assert.NotEqual(t, []int{1, 2, 3, 4, 5, 6, 7, 8}, items)
+	})
+
+	t.Run("shuffled copy leaves the original untouched", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewSliceProcessor[[]int](c)
+		items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+		result := p.Shuffled(items)
+		assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8}, items)
+		assert.ElementsMatch(t, items, result)
+	})
+}
+
+func TestSample(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		p := chaos.NewSliceProcessor[[]int](c)
+		items := []int{1, 2, 3}
+		assert.Equal(t, p.Sample(items, 10), p.Sample(items, 10))
+	})
+
+	t.Run("samples with replacement", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewSliceProcessor[[]int](c)
+		result := p.Sample([]int{1, 2, 3}, 100)
+		assert.Len(t, result, 100)
+		assert.Len(t, uniqueInts(result), 3)
+	})
+
+	t.Run("edge case: empty slice", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewSliceProcessor[[]int](c)
+		assert.Empty(t, p.Sample(nil, 10))
+	})
+}
+
+func TestSubset(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		p := chaos.NewSliceProcessor[[]int](c)
+		items := []int{1, 2, 3, 4, 5}
+		assert.Equal(t, p.Subset(items), p.Subset(items))
+	})
+
+	t.Run("keeps original order and covers all sizes", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewSliceProcessor[[]int](c)
+		items := []int{1, 2, 3, 4, 5}
+		sizes := make(map[int]bool)
+		for i := 0; i < 1000; i++ {
+			result := p.Subset(items)
+			assert.IsIncreasing(t, result)
+			sizes[len(result)] = true
+		}
+		assert.Len(t, sizes, 6)
+	})
+}
+
+func TestPartition(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		p := chaos.NewSliceProcessor[[]int](c)
+		items := []int{1, 2, 3, 4, 5}
+		assert.Equal(t, p.Partition(items, 3), p.Partition(items, 3))
+	})
+
+	t.Run("places every item in exactly one group", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewSliceProcessor[[]int](c)
+		items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+		for i := 0; i < 100; i++ {
+			groups := p.Partition(items, 3)
+			require.Len(t, groups, 3)
+			var all []int
+			for _, group := range groups {
+				all = append(all, group...)
+			}
+			assert.ElementsMatch(t, items, all)
+		}
+	})
+
+	t.Run("edge case: zero groups", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewSliceProcessor[[]int](c)
+		assert.Nil(t, p.Partition([]int{1, 2}, 0))
+	})
+}
+
+func TestPermutations(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		p := chaos.NewSliceProcessor[[]int](c)
+		items := []int{1, 2, 3, 4}
+		result1, err1 := p.Permutations(items, 5)
+		result2, err2 := p.Permutations(items, 5)
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.Equal(t, result1, result2)
+	})
+
+	t.Run("returns distinct permutations", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewSliceProcessor[[]int](c)
+		items := []int{1, 2, 3, 4}
+		result, err := p.Permutations(items, 24)
+		require.NoError(t, err)
+		keys := make(map[string]bool)
+		for _, permutation := range result {
+			assert.ElementsMatch(t, items, permutation)
+			keys[fmt.Sprintf("%v", permutation)] = true
+		}
+		assert.Len(t, keys, 24)
+	})
+
+	t.Run("handles long slices", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewSliceProcessor[[]int](c)
+		items := make([]int, 30)
+		for i := range items {
+			items[i] = i
+		}
+		result, err := p.Permutations(items, 3)
+		require.NoError(t, err)
+		assert.Len(t, result, 3)
+		assert.ElementsMatch(t, items, result[0])
+	})
+
+	t.Run("returns error when not enough permutations", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewSliceProcessor[[]int](c)
+		_, err := p.Permutations([]int{1, 2, 3}, 7)
+		assert.ErrorIs(t, err, chaos.ErrNotEnoughItemsInSlice)
+	})
+}
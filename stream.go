@@ -0,0 +1,133 @@
+package chaos
+
+// Iterator is a pull-style iterator.
+// Next returns the next value, or false once the iterator is exhausted.
+type Iterator[T any] interface {
+	Next() (T, bool)
+}
+
+// Stream is a lazily generated sequence of values.
+// Streams do not materialize their values, so they can feed arbitrarily large datasets
+// in constant memory. A stream is consumed as it is read.
+type Stream[T any] struct {
+	next func() (T, bool)
+}
+
+// NewStream returns a stream yielding the values returned by next, until it returns false.
+func NewStream[T any](next func() (T, bool)) *Stream[T] {
+	return &Stream[T]{
+		next: next,
+	}
+}
+
+// Next returns the next value of the stream, or false once the stream is exhausted.
+func (s *Stream[T]) Next() (T, bool) {
+	return s.next()
+}
+
+// Take returns a stream yielding at most the n next values of s.
+func (s *Stream[T]) Take(n int) *Stream[T] {
+	taken := 0
+	return NewStream(func() (T, bool) {
+		if taken >= n {
+			var zero T
+			return zero, false
+		}
+		taken++
+		return s.Next()
+	})
+}
+
+// All returns a push-style iterator over the remaining values of the stream.
+// Its signature matches iter.Seq, so it can be used in range loops from Go 1.23.
+func (s *Stream[T]) All() func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		for v, ok := s.Next(); ok; v, ok = s.Next() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Collect reads every remaining value of the iterator into a slice.
+// The iterator must be finite, see Stream.Take.
+func Collect[T any](it Iterator[T]) []T {
+	var values []T
+	for v, ok := it.Next(); ok; v, ok = it.Next() {
+		values = append(values, v)
+	}
+	return values
+}
+
+// Generate returns an infinite stream of values produced by gen.
+func Generate[T any](c *Chaos, gen func(c *Chaos) T) *Stream[T] {
+	return NewStream(func() (T, bool) {
+		return gen(c), true
+	})
+}
+
+// Ints returns an infinite stream of random numbers between 0 and high included.
+func Ints(high int) *Stream[int] {
+	return singleton.Ints(high)
+}
+
+// Ints returns an infinite stream of deterministic numbers between 0 and high included.
+// The whole stream is drawn from a single random source, so it is much faster than calling Int repeatedly.
+// If high <= 0, the stream only yields 0.
+func (c *Chaos) Ints(high int) *Stream[int] {
+	r := c.rand()
+	return NewStream(func() (int, bool) {
+		if high <= 0 {
+			return 0, true
+		}
+		return r.Intn(high + 1), true
+	})
+}
+
+// Strings returns an infinite stream of random strings of <length> alphanumerical characters.
+func Strings(length int) *Stream[string] {
+	return singleton.Strings(length)
+}
+
+// Strings returns an infinite stream of deterministic strings of <length> alphanumerical characters.
+// The whole stream is drawn from a single random source, so it is much faster than calling String repeatedly.
+func (c *Chaos) Strings(length int) *Stream[string] {
+	r := c.rand()
+	return NewStream(func() (string, bool) {
+		ret := make([]byte, max(length, 0))
+		for i := range ret {
+			ret[i] = alphanumericalChars[r.Intn(len(alphanumericalChars))]
+		}
+		return string(ret), true
+	})
+}
+
+// ReservoirSample returns k random values read from the iterator.
+func ReservoirSample[T any](it Iterator[T], k int) []T {
+	return NewSliceProcessor[[]T, T](singleton).ReservoirSample(it, k)
+}
+
+// ReservoirSample reads the whole iterator and returns k of its values, each of them being equally likely.
+// It only keeps k values in memory, so it can sample streams too large for UniqueItems.
+// If the iterator yields less than k values, they are all returned.
+// The iterator must be finite, see Stream.Take.
+func (s *SliceProcessor[S, T]) ReservoirSample(it Iterator[T], k int) S {
+	if k <= 0 {
+		return S{}
+	}
+	r := s.c.rand()
+	reservoir := make(S, 0, k)
+	seen := 0
+	for v, ok := it.Next(); ok; v, ok = it.Next() {
+		seen++
+		if len(reservoir) < k {
+			reservoir = append(reservoir, v)
+			continue
+		}
+		if j := r.Intn(seen); j < k {
+			reservoir[j] = v
+		}
+	}
+	return reservoir
+}
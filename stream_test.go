@@ -0,0 +1,112 @@
+package chaos_test
+
+import (
+	"testing"
+
+	"github.com/raphoester/chaos"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		s1 := chaos.New(t.Name()).Ints(100).Take(100)
+		s2 := chaos.New(t.Name()).Ints(100).Take(100)
+		assert.Equal(t, chaos.Collect(s1), chaos.Collect(s2))
+	})
+
+	t.Run("respects upper bound", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for _, v := range chaos.Collect(c.Ints(10).Take(1000)) {
+			assert.GreaterOrEqual(t, v, 0)
+			assert.LessOrEqual(t, v, 10)
+		}
+	})
+
+	t.Run("take limits the stream", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		s := c.Strings(5).Take(3)
+		assert.Len(t, chaos.Collect(s), 3)
+		_, ok := s.Next()
+		assert.False(t, ok)
+	})
+
+	t.Run("strings respect length", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for _, v := range chaos.Collect(c.Strings(8).Take(100)) {
+			assert.Regexp(t, `^[a-zA-Z0-9]{8}$`, v)
+		}
+	})
+
+	t.Run("generates from any generator", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		emails := chaos.Collect(chaos.Generate(c, (*chaos.Chaos).Email).Take(10))
+		assert.Len(t, emails, 10)
+		assert.Contains(t, emails[0], "@")
+	})
+
+	t.Run("push iterator stops early", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		count := 0
+		c.Ints(10).All()(func(int) bool {
+			count++
+			return count < 5
+		})
+		assert.Equal(t, 5, count)
+	})
+
+	t.Run("permutations are iterators", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		assert.Len(t, chaos.Collect[uint64](c.Permutation(10)), 10)
+	})
+}
+
+func TestReservoirSample(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		p1 := chaos.NewSliceProcessor[[]int](chaos.New(t.Name()))
+		p2 := chaos.NewSliceProcessor[[]int](chaos.New(t.Name()))
+		assert.Equal(t,
+			p1.ReservoirSample(countTo(1000), 10),
+			p2.ReservoirSample(countTo(1000), 10))
+	})
+
+	t.Run("returns distinct stream values", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewSliceProcessor[[]int](c)
+		result := p.ReservoirSample(countTo(1000), 10)
+		assert.Len(t, result, 10)
+		assert.Len(t, uniqueInts(result), 10)
+	})
+
+	t.Run("selects all values with approximately equal probability", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewSliceProcessor[[]int](c)
+		counts := make(map[int]int)
+		const iterations = 10000
+		for i := 0; i < iterations; i++ {
+			for _, v := range p.ReservoirSample(countTo(10), 2) {
+				counts[v]++
+			}
+		}
+		expectedCount := float64(iterations*2) / 10
+		for v, count := range counts {
+			assert.InDelta(t, expectedCount, count, expectedCount*0.1, "value %d", v)
+		}
+	})
+
+	t.Run("returns every value of short streams", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		p := chaos.NewSliceProcessor[[]int](c)
+		assert.ElementsMatch(t, []int{0, 1, 2}, p.ReservoirSample(countTo(3), 10))
+	})
+}
+
+func countTo(n int) *chaos.Stream[int] {
+	i := 0
+	return chaos.NewStream(func() (int, bool) {
+		if i >= n {
+			return 0, false
+		}
+		i++
+		return i - 1, true
+	})
+}
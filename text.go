@@ -0,0 +1,216 @@
+package chaos
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// loremWords is the word list used by the default text generators.
+var loremWords = []string{
+	"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit",
+	"sed", "do", "eiusmod", "tempor", "incididunt", "ut", "labore", "et", "dolore",
+	"magna", "aliqua", "enim", "ad", "minim", "veniam", "quis", "nostrud",
+	"exercitation", "ullamco", "laboris", "nisi", "aliquip", "ex", "ea", "commodo",
+	"consequat", "duis", "aute", "irure", "in", "reprehenderit", "voluptate",
+	"velit", "esse", "cillum", "eu", "fugiat", "nulla", "pariatur", "excepteur",
+	"sint", "occaecat", "cupidatat", "non", "proident", "sunt", "culpa", "qui",
+	"officia", "deserunt", "mollit", "anim", "id", "est", "laborum", "perspiciatis",
+	"unde", "omnis", "iste", "natus", "error", "voluptatem", "accusantium",
+	"doloremque", "laudantium", "totam", "rem", "aperiam", "eaque", "ipsa", "quae",
+	"ab", "illo", "inventore", "veritatis", "quasi", "architecto", "beatae", "vitae",
+	"dicta", "explicabo", "nemo", "ipsam", "quia", "voluptas", "aspernatur", "aut",
+	"odit", "fugit", "consequuntur", "magni", "dolores", "eos", "ratione", "sequi",
+	"nesciunt", "neque", "porro", "quisquam", "dolorem", "adipisci", "numquam",
+	"eius", "modi", "tempora", "incidunt", "magnam", "quaerat", "minima", "nostrum",
+	"exercitationem", "ullam", "corporis", "suscipit", "laboriosam", "aliquid",
+	"autem", "vel", "eum", "iure", "quam", "nihil", "molestiae", "illum", "quo",
+	"at", "vero", "accusamus", "iusto", "odio", "dignissimos", "ducimus",
+	"blanditiis", "praesentium", "deleniti", "atque", "corrupti", "quos", "quas",
+	"molestias", "excepturi", "occaecati", "cupiditate", "provident", "similique",
+	"mollitia", "animi", "dolorum", "fuga", "harum", "quidem", "rerum", "facilis",
+	"expedita", "distinctio", "nam", "libero", "tempore", "cum", "soluta", "nobis",
+	"eligendi", "optio", "cumque", "impedit", "minus", "quod", "maxime", "placeat",
+	"facere", "possimus", "assumenda", "repellendus", "temporibus", "quibusdam",
+	"officiis", "debitis", "necessitatibus", "saepe", "eveniet", "voluptates",
+	"repudiandae", "recusandae", "itaque", "earum", "hic", "tenetur", "sapiente",
+	"delectus", "reiciendis", "voluptatibus", "maiores", "alias", "perferendis",
+	"doloribus", "asperiores", "repellat",
+}
+
+const (
+	paragraphMinWords = 4
+	paragraphMaxWords = 12
+)
+
+// wordSource picks the next word of a sentence given the words already written.
+type wordSource interface {
+	next(c *Chaos, previous []string) string
+}
+
+type loremSource struct{}
+
+func (loremSource) next(c *Chaos, _ []string) string {
+	return loremWords[c.Int(len(loremWords)-1)]
+}
+
+// textGenerator builds sentences, paragraphs and texts out of a wordSource.
+type textGenerator struct {
+	c      *Chaos
+	source wordSource
+}
+
+func (g textGenerator) words(count int) []string {
+	count = max(count, 0)
+	words := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		words = append(words, g.source.next(g.c, words))
+	}
+	return words
+}
+
+func (g textGenerator) sentence(minWords, maxWords int) string {
+	if minWords > maxWords {
+		minWords, maxWords = maxWords, minWords
+	}
+	if minWords < 1 {
+		minWords = 1
+	}
+	if maxWords < 1 {
+		maxWords = 1
+	}
+	words := g.words(g.c.IntBetween(minWords, maxWords))
+	last := len(words) - 1
+	words[last] = strings.TrimRight(words[last], ".!?,;:")
+	return capitalize(strings.Join(words, " ")) + "."
+}
+
+func (g textGenerator) paragraph(sentences int) string {
+	sentences = max(sentences, 0)
+	parts := make([]string, 0, sentences)
+	for i := 0; i < sentences; i++ {
+		parts = append(parts, g.sentence(paragraphMinWords, paragraphMaxWords))
+	}
+	return strings.Join(parts, " ")
+}
+
+func (g textGenerator) text(maxChars int) string {
+	if maxChars <= 0 {
+		return ""
+	}
+
+	ret := ""
+	for {
+		sentence := g.sentence(paragraphMinWords, paragraphMaxWords)
+		candidate := sentence
+		if ret != "" {
+			candidate = ret + " " + sentence
+		}
+		if utf8.RuneCountInString(candidate) > maxChars {
+			break
+		}
+		ret = candidate
+	}
+	if ret != "" {
+		return ret
+	}
+
+	// Not even a single sentence fits: fall back to as many words as possible.
+	for {
+		word := g.source.next(g.c, strings.Fields(ret))
+		candidate := word
+		if ret != "" {
+			candidate = ret + " " + word
+		}
+		if utf8.RuneCountInString(candidate) > maxChars {
+			break
+		}
+		ret = candidate
+	}
+	if ret == "" {
+		return string([]rune(g.source.next(g.c, nil))[:maxChars])
+	}
+	return ret
+}
+
+func (g textGenerator) title(minWords, maxWords int) string {
+	if minWords > maxWords {
+		minWords, maxWords = maxWords, minWords
+	}
+	if minWords < 1 {
+		minWords = 1
+	}
+	if maxWords < 1 {
+		maxWords = 1
+	}
+	words := g.words(g.c.IntBetween(minWords, maxWords))
+	for i, word := range words {
+		words[i] = capitalize(strings.Trim(word, ".!?,;:"))
+	}
+	return strings.Join(words, " ")
+}
+
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// Word returns a random lorem ipsum word.
+func Word() string {
+	return singleton.Word()
+}
+
+// Word returns a deterministic lorem ipsum word.
+func (c *Chaos) Word() string {
+	return loremSource{}.next(c, nil)
+}
+
+// Sentence returns a random sentence of minWords to maxWords lorem ipsum words.
+// The sentence starts with a capital letter and ends with a period.
+func Sentence(minWords, maxWords int) string {
+	return singleton.Sentence(minWords, maxWords)
+}
+
+// Sentence returns a deterministic sentence of minWords to maxWords lorem ipsum words.
+// The sentence starts with a capital letter and ends with a period.
+// Behavior:
+//   - If minWords > maxWords, the values are swapped.
+//   - The sentence always contains at least one word.
+func (c *Chaos) Sentence(minWords, maxWords int) string {
+	return textGenerator{c: c, source: loremSource{}}.sentence(minWords, maxWords)
+}
+
+// Paragraph returns a random paragraph made of n lorem ipsum sentences.
+func Paragraph(n int) string {
+	return singleton.Paragraph(n)
+}
+
+// Paragraph returns a deterministic paragraph made of n lorem ipsum sentences.
+// If n <= 0, it returns an empty string.
+func (c *Chaos) Paragraph(n int) string {
+	return textGenerator{c: c, source: loremSource{}}.paragraph(n)
+}
+
+// Text returns a random lorem ipsum text of at most maxChars characters.
+func Text(maxChars int) string {
+	return singleton.Text(maxChars)
+}
+
+// Text returns a deterministic lorem ipsum text of at most maxChars characters.
+// The text is made of whole sentences whenever at least one of them fits.
+func (c *Chaos) Text(maxChars int) string {
+	return textGenerator{c: c, source: loremSource{}}.text(maxChars)
+}
+
+// Title returns a random title of minWords to maxWords capitalized lorem ipsum words.
+func Title(minWords, maxWords int) string {
+	return singleton.Title(minWords, maxWords)
+}
+
+// Title returns a deterministic title of minWords to maxWords capitalized lorem ipsum words.
+func (c *Chaos) Title(minWords, maxWords int) string {
+	return textGenerator{c: c, source: loremSource{}}.title(minWords, maxWords)
+}
@@ -0,0 +1,127 @@
+package chaos_test
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/raphoester/chaos"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWord(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.Word(), c.Word())
+	})
+
+	t.Run("produces lowercase words", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 1000; i++ {
+			word := c.Word()
+			assert.NotEmpty(t, word)
+			assert.Equal(t, strings.ToLower(word), word)
+		}
+	})
+}
+
+func TestSentence(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.Sentence(3, 10), c.Sentence(3, 10))
+	})
+
+	t.Run("respects word count", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 1000; i++ {
+			words := strings.Fields(c.Sentence(3, 10))
+			assert.GreaterOrEqual(t, len(words), 3)
+			assert.LessOrEqual(t, len(words), 10)
+		}
+	})
+
+	t.Run("is capitalized and ends with a period", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 100; i++ {
+			sentence := c.Sentence(1, 5)
+			first, _ := utf8.DecodeRuneInString(sentence)
+			assert.True(t, unicode.IsUpper(first))
+			assert.True(t, strings.HasSuffix(sentence, "."))
+		}
+	})
+
+	t.Run("edge case: zero words", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		assert.Len(t, strings.Fields(c.Sentence(0, 0)), 1)
+	})
+}
+
+func TestParagraph(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.Paragraph(3), c.Paragraph(3))
+	})
+
+	t.Run("contains the requested number of sentences", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 10; i++ {
+			assert.Equal(t, i, strings.Count(c.Paragraph(i), "."))
+		}
+	})
+
+	t.Run("edge case: negative count", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		assert.Equal(t, "", c.Paragraph(-1))
+	})
+}
+
+func TestText(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.Text(200), c.Text(200))
+	})
+
+	t.Run("respects max length", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 300; i++ {
+			result := c.Text(i)
+			assert.LessOrEqual(t, utf8.RuneCountInString(result), i)
+		}
+	})
+
+	t.Run("uses whole sentences when possible", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		result := c.Text(1000)
+		assert.Greater(t, len(result), 500)
+		assert.True(t, strings.HasSuffix(result, "."))
+	})
+
+	t.Run("edge case: zero length", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		assert.Empty(t, c.Text(0))
+	})
+}
+
+func TestTitle(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		c.Fix()
+		assert.Equal(t, c.Title(2, 5), c.Title(2, 5))
+	})
+
+	t.Run("capitalizes every word", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		words := strings.Fields(c.Title(2, 5))
+		assert.GreaterOrEqual(t, len(words), 2)
+		assert.LessOrEqual(t, len(words), 5)
+		for _, word := range words {
+			first, _ := utf8.DecodeRuneInString(word)
+			assert.True(t, unicode.IsUpper(first))
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package chaos
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// maxUniqueRetries is the number of values drawn before a unique generator gives up.
+const maxUniqueRetries = 1000
+
+var (
+	ErrUniqueExhausted = errors.New("no unique value left")
+)
+
+// UniqueGenerator wraps the generators of a Chaos so they never return the same value twice.
+// Issued values are remembered per scope and per kind of value: ints issued by Int and
+// IntBetween share the same set, while strings issued by String have their own.
+type UniqueGenerator struct {
+	c      *Chaos
+	scope  string
+	issued map[string]map[any]struct{}
+}
+
+// Unique returns the default unique generator of the singleton chaos.
+func Unique() *UniqueGenerator {
+	return singleton.Unique()
+}
+
+// Unique returns the default unique generator of the chaos.
+// Successive calls return the same generator, so issued values are remembered across calls.
+func (c *Chaos) Unique() *UniqueGenerator {
+	return c.UniqueScope("")
+}
+
+// UniqueScope returns the unique generator of the singleton chaos for the named scope.
+func UniqueScope(name string) *UniqueGenerator {
+	return singleton.UniqueScope(name)
+}
+
+// UniqueScope returns the unique generator of the chaos for the named scope.
+// Values issued in one scope do not prevent other scopes from issuing them,
+// so a scope typically matches a unique constraint, like "users.email".
+func (c *Chaos) UniqueScope(name string) *UniqueGenerator {
+	if c.uniques == nil {
+		c.uniques = make(map[string]*UniqueGenerator)
+	}
+	u, ok := c.uniques[name]
+	if !ok {
+		u = &UniqueGenerator{
+			c:      c,
+			scope:  name,
+			issued: make(map[string]map[any]struct{}),
+		}
+		c.uniques[name] = u
+	}
+	return u
+}
+
+// Reset forgets every value issued by the generator.
+func (u *UniqueGenerator) Reset() {
+	u.issued = make(map[string]map[any]struct{})
+}
+
+func (u *UniqueGenerator) set(kind string) map[any]struct{} {
+	set, ok := u.issued[kind]
+	if !ok {
+		set = make(map[any]struct{})
+		u.issued[kind] = set
+	}
+	return set
+}
+
+func (u *UniqueGenerator) exhausted(kind string, reason string) error {
+	return errors.Join(ErrUniqueExhausted,
+		fmt.Errorf("unique %s in scope %q: %s", kind, u.scope, reason))
+}
+
+// UniqueValue returns a value generated by gen that was never issued for kind by u.
+// Values are drawn again until an unseen one is found.
+// If none is found after 1000 attempts, it returns an error.
+func UniqueValue[T comparable](u *UniqueGenerator, kind string, gen func(c *Chaos) T) (T, error) {
+	set := u.set(kind)
+	for i := 0; i < maxUniqueRetries; i++ {
+		value := gen(u.c)
+		if _, ok := set[value]; !ok {
+			set[value] = struct{}{}
+			return value, nil
+		}
+	}
+	var zero T
+	return zero, u.exhausted(kind, fmt.Sprintf("no new value after %d attempts", maxUniqueRetries))
+}
+
+// Int returns an int between 0 and n (inclusive) that was never issued by the generator.
+// If every value of the range was already issued, it returns an error.
+func (u *UniqueGenerator) Int(n int) (int, error) {
+	if n < 0 {
+		n = 0
+	}
+	return u.IntBetween(0, n)
+}
+
+// IntBetween returns an int between min and max (inclusive) that was never issued by the generator.
+// If every value of the range was already issued, it returns an error.
+func (u *UniqueGenerator) IntBetween(min, max int) (int, error) {
+	if min > max {
+		min, max = max, min
+	}
+	const kind = "int"
+	set := u.set(kind)
+
+	candidate := 0
+	for i := 0; i < maxUniqueRetries; i++ {
+		candidate = u.c.IntBetween(min, max)
+		if _, ok := set[candidate]; !ok {
+			set[candidate] = struct{}{}
+			return candidate, nil
+		}
+	}
+
+	// The range is almost exhausted: walk from the last candidate to the next free value,
+	// and give up after a full cycle. A size of 0 is the whole range of int, which overflows.
+	size := uint64(max-min) + 1
+	for step := uint64(1); size == 0 || step < size; step++ {
+		if candidate == max {
+			candidate = min
+		} else {
+			candidate++
+		}
+		if _, ok := set[candidate]; !ok {
+			set[candidate] = struct{}{}
+			return candidate, nil
+		}
+	}
+	return 0, u.exhausted(kind, fmt.Sprintf("all the %d values between %d and %d were issued", size, min, max))
+}
+
+// Int64 returns an int64 between 0 and n (inclusive) that was never issued by the generator.
+func (u *UniqueGenerator) Int64(n int64) (int64, error) {
+	return UniqueValue(u, "int64", func(c *Chaos) int64 {
+		return c.Int64(n)
+	})
+}
+
+// String returns a string of <length> alphanumerical characters that was never issued by the generator.
+func (u *UniqueGenerator) String(length int) (string, error) {
+	return UniqueValue(u, "string", func(c *Chaos) string {
+		return c.String(length)
+	})
+}
+
+// Word returns a lorem ipsum word that was never issued by the generator.
+func (u *UniqueGenerator) Word() (string, error) {
+	return UniqueValue(u, "word", func(c *Chaos) string {
+		return c.Word()
+	})
+}
+
+// Email returns an email address that was never issued by the generator.
+func (u *UniqueGenerator) Email() (string, error) {
+	return UniqueValue(u, "email", func(c *Chaos) string {
+		return c.Email()
+	})
+}
+
+// UUID returns a UUID that was never issued by the generator.
+func (u *UniqueGenerator) UUID() (uuid.UUID, error) {
+	return UniqueValue(u, "uuid", func(c *Chaos) uuid.UUID {
+		return c.UUID()
+	})
+}
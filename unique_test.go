@@ -0,0 +1,133 @@
+package chaos_test
+
+import (
+	"testing"
+
+	"github.com/raphoester/chaos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnique(t *testing.T) {
+	t.Run("deterministic output", func(t *testing.T) {
+		c1 := chaos.New(t.Name())
+		c2 := chaos.New(t.Name())
+		for i := 0; i < 50; i++ {
+			v1, err1 := c1.Unique().Int(100)
+			v2, err2 := c2.Unique().Int(100)
+			require.NoError(t, err1)
+			require.NoError(t, err2)
+			assert.Equal(t, v1, v2)
+		}
+	})
+
+	t.Run("issues every value of a small range exactly once", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		results := make(map[int]bool)
+		for i := 0; i <= 100; i++ {
+			result, err := c.Unique().Int(100)
+			require.NoError(t, err)
+			assert.False(t, results[result], "Expected unique result")
+			results[result] = true
+		}
+		assert.Len(t, results, 101)
+
+		_, err := c.Unique().Int(100)
+		assert.ErrorIs(t, err, chaos.ErrUniqueExhausted)
+	})
+
+	t.Run("issues many values of a large range", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		results := make(map[int]bool)
+		for i := 0; i < 20_000; i++ {
+			result, err := c.Unique().IntBetween(0, 1_000_000)
+			require.NoError(t, err)
+			results[result] = true
+		}
+		assert.Len(t, results, 20_000)
+	})
+
+	t.Run("shares issued ints between Int and IntBetween", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		for i := 0; i < 5; i++ {
+			_, err := c.Unique().IntBetween(10, 14)
+			require.NoError(t, err)
+		}
+		_, err := c.Unique().IntBetween(10, 14)
+		assert.ErrorIs(t, err, chaos.ErrUniqueExhausted)
+
+		result, err := c.Unique().Int(15)
+		require.NoError(t, err)
+		assert.True(t, result < 10 || result == 15)
+	})
+
+	t.Run("short strings do not collide", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		results := make(map[string]bool)
+		for i := 0; i < 3000; i++ {
+			result, err := c.Unique().String(2)
+			require.NoError(t, err)
+			assert.False(t, results[result], "Expected unique result")
+			results[result] = true
+		}
+	})
+
+	t.Run("scopes are independent", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		_, err := c.UniqueScope("a").Int(0)
+		require.NoError(t, err)
+		_, err = c.UniqueScope("a").Int(0)
+		assert.ErrorIs(t, err, chaos.ErrUniqueExhausted)
+
+		result, err := c.UniqueScope("b").Int(0)
+		require.NoError(t, err)
+		assert.Equal(t, 0, result)
+	})
+
+	t.Run("reset forgets issued values", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		_, err := c.Unique().Int(0)
+		require.NoError(t, err)
+		c.Unique().Reset()
+		_, err = c.Unique().Int(0)
+		assert.NoError(t, err)
+	})
+
+	t.Run("returns error once retries are exhausted", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		var err error
+		for i := 0; i < 1000 && err == nil; i++ {
+			_, err = c.Unique().Word()
+		}
+		assert.ErrorIs(t, err, chaos.ErrUniqueExhausted)
+	})
+
+	t.Run("custom generators", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		results := make(map[bool]bool)
+		for i := 0; i < 2; i++ {
+			result, err := chaos.UniqueValue(c.Unique(), "bool", func(c *chaos.Chaos) bool {
+				return c.Bool()
+			})
+			require.NoError(t, err)
+			results[result] = true
+		}
+		assert.Len(t, results, 2)
+
+		_, err := chaos.UniqueValue(c.Unique(), "bool", func(c *chaos.Chaos) bool {
+			return c.Bool()
+		})
+		assert.ErrorIs(t, err, chaos.ErrUniqueExhausted)
+	})
+
+	t.Run("emails do not collide", func(t *testing.T) {
+		c := chaos.New(t.Name())
+		results := make(map[string]bool)
+		for i := 0; i < 1000; i++ {
+			result, err := c.Unique().Email()
+			require.NoError(t, err)
+			assert.False(t, results[result], "Expected unique result")
+			results[result] = true
+		}
+	})
+}